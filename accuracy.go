@@ -0,0 +1,53 @@
+package temperedgo
+
+import (
+	"sync"
+)
+
+// AccuracySpec is a model's stated accuracy, as a ± bound in degrees
+// Celsius and percentage points of relative humidity. A zero bound means
+// unknown.
+type AccuracySpec struct {
+	Temperature float64 `json:"temperature"`
+	Humidity    float64 `json:"humidity"`
+}
+
+var (
+	accuracyLock  sync.RWMutex
+	accuracySpecs = map[string]AccuracySpec{}
+)
+
+// SetAccuracy registers the accuracy of the model reporting typeName, so
+// that ReadAll attaches it to readings from such devices as their
+// uncertainty. Nothing is registered by default, since datasheet figures
+// vary between hardware revisions sold under the same name.
+func SetAccuracy(typeName string, spec AccuracySpec) {
+	accuracyLock.Lock()
+	defer accuracyLock.Unlock()
+
+	accuracySpecs[typeName] = spec
+}
+
+// Accuracy returns the spec registered for typeName.
+func Accuracy(typeName string) (AccuracySpec, bool) {
+	accuracyLock.RLock()
+	defer accuracyLock.RUnlock()
+
+	spec, ok := accuracySpecs[typeName]
+	return spec, ok
+}
+
+func applyAccuracy(typeName string, r *Reading) {
+	spec, ok := Accuracy(typeName)
+	if !ok {
+		return
+	}
+	if r.Temperature != nil && spec.Temperature > 0 {
+		val := spec.Temperature
+		r.TemperatureUncertainty = &val
+	}
+	if r.Humidity != nil && spec.Humidity > 0 {
+		val := spec.Humidity
+		r.HumidityUncertainty = &val
+	}
+}
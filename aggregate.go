@@ -0,0 +1,44 @@
+package temperedgo
+
+// AverageReadings combines readings into one synthetic reading. Temperature
+// and humidity are averaged independently over the readings that have them,
+// so in a mix of temperature-only and humidity-capable readings the
+// temperature is the mean of all of them and the humidity the mean of just
+// the humidity-capable ones. A measurement no reading has is left nil. The
+// result takes the latest Time, keeps DeviceID only if every reading shares
+// it, and has a SensorNum of -1.
+func AverageReadings(rs []Reading) Reading {
+	avg := Reading{SensorNum: -1}
+	var tempSum, humSum float64
+	var tempN, humN int
+	for n, r := range rs {
+		if r.Time.After(avg.Time) {
+			avg.Time = r.Time
+		}
+		if n == 0 {
+			avg.DeviceID = r.DeviceID
+		} else if avg.DeviceID != r.DeviceID {
+			avg.DeviceID = ""
+		}
+		if r.Temperature != nil {
+			tempSum += *r.Temperature
+			tempN++
+		}
+		if r.Humidity != nil {
+			humSum += *r.Humidity
+			humN++
+		}
+	}
+
+	if tempN > 0 {
+		val := tempSum / float64(tempN)
+		avg.Temperature = &val
+		avg.Type |= TEMPERED_SENSOR_TYPE_TEMPERATURE
+	}
+	if humN > 0 {
+		val := humSum / float64(humN)
+		avg.Humidity = &val
+		avg.Type |= TEMPERED_SENSOR_TYPE_HUMIDITY
+	}
+	return avg
+}
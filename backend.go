@@ -0,0 +1,115 @@
+package temperedgo
+
+// Backend is the set of native library operations the package is built on,
+// so that libtempered can be swapped out, for instance for a FakeBackend in
+// tests. The default is the cgo binding to libtempered, or DefaultFake when
+// built without cgo. Backends are told apart with ==, so an
+// implementation must be a comparable type, such as a pointer.
+type Backend interface {
+	Init() error
+	Exit() error
+	Enumerate() ([]TemperedDevice, error)
+	Open(td *TemperedDevice) (DeviceHandle, error)
+}
+
+// DeviceHandle is an open device in a Backend. ReadSensors refreshes the
+// values that Temperature and Humidity return; the bools report success.
+type DeviceHandle interface {
+	Close()
+	SensorCount() int
+	SensorType(sensorNum int) TemperedSensorType
+	ReadSensors() bool
+	Temperature(sensorNum int) (float64, bool)
+	Humidity(sensorNum int) (float64, bool)
+}
+
+// batchReader is implemented by device handles that can fetch every sensor's
+// values more cheaply than one call per value.
+type batchReader interface {
+	readAll(count int) []sensorValues
+}
+
+// commandSender is implemented by device handles that can write raw reports
+// to the device.
+type commandSender interface {
+	sendCommand(data []byte) error
+}
+
+// stringDescriptor is implemented by device handles that can read the USB
+// manufacturer and product strings. An empty string means the device has
+// none.
+type stringDescriptor interface {
+	manufacturer() string
+	product() string
+}
+
+// linkReporter is implemented by device handles that can report the state
+// of a wireless link to the sensor. ok is false if the device has no such
+// link.
+type linkReporter interface {
+	linkStatus() (status LinkStatus, ok bool)
+}
+
+type sensorValues struct {
+	sensorType    TemperedSensorType
+	temperature   float64
+	temperatureOk bool
+	humidity      float64
+	humidityOk    bool
+}
+
+func (t *Tempered) backendOrDefault() Backend {
+	if t.Backend != nil {
+		return t.Backend
+	}
+	return nativeBackend
+}
+
+// backendOrDefault is the backend of the Tempered that enumerated the device.
+func (t *TemperedDevice) backendOrDefault() Backend {
+	if t.backend != nil {
+		return t.backend
+	}
+	return nativeBackend
+}
+
+func readSensorValues(dev DeviceHandle, count int) []sensorValues {
+	if br, ok := dev.(batchReader); ok {
+		return br.readAll(count)
+	}
+
+	values := make([]sensorValues, count)
+	for n := range values {
+		v := &values[n]
+		v.sensorType = dev.SensorType(n)
+		if v.sensorType.IsType(TEMPERED_SENSOR_TYPE_TEMPERATURE) {
+			v.temperature, v.temperatureOk = dev.Temperature(n)
+		}
+		if v.sensorType.IsType(TEMPERED_SENSOR_TYPE_HUMIDITY) {
+			v.humidity, v.humidityOk = dev.Humidity(n)
+		}
+	}
+	return values
+}
+
+// Available reports whether libtempered can be used here: the package was
+// built with cgo and the library can be inited. Without cgo the default
+// backend is DefaultFake, and Available reports false. It does not open any
+// device.
+func Available() bool {
+	if !CgoEnabled() {
+		return false
+	}
+
+	libLock.Lock()
+	defer libLock.Unlock()
+
+	if libRefs[nativeBackend] > 0 {
+		return true
+	}
+	if err := nativeBackend.Init(); err != nil {
+		return false
+	}
+	nativeBackend.Exit()
+	return true
+}
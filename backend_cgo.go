@@ -0,0 +1,227 @@
+//go:build cgo
+
+package temperedgo
+
+// #cgo LDFLAGS: -ltempered -lhidapi-hidraw
+// #include <tempered.h>
+// #include <stdlib.h>
+//
+// // hidapi's header lives at different paths on different systems, so
+// // declare the little of it SendCommand uses. libtempered is built on
+// // hidapi, which is linked above.
+// typedef struct hid_device_ hid_device;
+// hid_device *hid_open_path(const char *path);
+// int hid_write(hid_device *dev, const unsigned char *data, size_t length);
+// void hid_close(hid_device *dev);
+//
+// static void tempered_go_read_all(tempered_device *dev, int count, int *types,
+// 		float *temps, bool *temp_ok, float *hums, bool *hum_ok) {
+// 	for (int i = 0; i < count; i++) {
+// 		types[i] = tempered_get_sensor_type(dev, i);
+// 		if (types[i] & TEMPERED_SENSOR_TYPE_TEMPERATURE) {
+// 			temp_ok[i] = tempered_get_temperature(dev, i, &temps[i]);
+// 		}
+// 		if (types[i] & TEMPERED_SENSOR_TYPE_HUMIDITY) {
+// 			hum_ok[i] = tempered_get_humidity(dev, i, &hums[i]);
+// 		}
+// 	}
+// }
+import "C"
+
+import (
+	"runtime"
+	"unsafe"
+)
+
+type cgoBackend struct{}
+
+var nativeBackend Backend = cgoBackend{}
+
+// CgoEnabled reports whether the package was built with cgo, and so has the
+// libtempered backend. Without cgo only other Backends, such as
+// FakeBackend, can be used.
+func CgoEnabled() bool {
+	return true
+}
+
+// DefaultFake returns the FakeBackend that is the default backend when the
+// package is built without cgo, or nil when libtempered is the default.
+func DefaultFake() *FakeBackend {
+	return nil
+}
+
+type cgoDevice struct {
+	dev  *C.tempered_device
+	path string
+}
+
+// nativeError converts a libtempered error string into a TemperedError for
+// op wrapping sentinel. libtempered reports failures only as formatted
+// messages (hidapi gives it nothing better), so there is no numeric error
+// code to carry alongside the text. Some failure paths don't set the error
+// string at all, leaving Message empty.
+func nativeError(op string, sentinel error, errCstr *C.char) error {
+	te := &TemperedError{Op: op, Err: sentinel}
+	if errCstr != nil {
+		te.Message = C.GoString(errCstr)
+		C.free(unsafe.Pointer(errCstr))
+	}
+	return te
+}
+
+func (cgoBackend) Init() error {
+	var errCstr *C.char
+	if !C.tempered_init(&errCstr) {
+		return nativeError(OP_INIT, ERR_INIT_FAILED, errCstr)
+	}
+	return nil
+}
+
+func (cgoBackend) Exit() error {
+	var errCstr *C.char
+	if !C.tempered_exit(&errCstr) {
+		return nativeError(OP_EXIT, ERR_EXIT_FAILED, errCstr)
+	}
+	return nil
+}
+
+func (cgoBackend) Enumerate() ([]TemperedDevice, error) {
+	var errCstr *C.char
+	var cDevices *C.struct_tempered_device_list
+	cDevices = C.tempered_enumerate(&errCstr)
+	if cDevices == nil {
+		return nil, nativeError(OP_ENUMERATE, ERR_ENUMERATE_FAILED, errCstr)
+	}
+	defer func() {
+		C.tempered_free_device_list(cDevices)
+	}()
+
+	tds := []TemperedDevice{}
+	for dev := cDevices; dev != nil; dev = dev.next {
+		td := TemperedDevice{
+			Path:            C.GoString(dev.path),
+			TypeName:        C.GoString(dev.type_name),
+			VendorId:        uint(dev.vendor_id),
+			ProductId:       uint(dev.product_id),
+			InterfaceNumber: int(dev.interface_number),
+		}
+		tds = append(tds, td)
+	}
+
+	return tds, nil
+}
+
+func (cgoBackend) Open(t *TemperedDevice) (DeviceHandle, error) {
+	devList := C.struct_tempered_device_list{
+		next:             nil,
+		path:             C.CString(t.Path),
+		type_name:        C.CString(t.TypeName),
+		vendor_id:        C.ushort(t.VendorId),
+		product_id:       C.ushort(t.ProductId),
+		interface_number: C.int(t.InterfaceNumber),
+	}
+	defer func() {
+		C.free(unsafe.Pointer(devList.path))
+		C.free(unsafe.Pointer(devList.type_name))
+	}()
+
+	var errCstr *C.char
+	devRet := C.tempered_open(&devList, &errCstr)
+	if devRet == nil {
+		return nil, nativeError(OP_OPEN, ERR_OPEN_FAILED, errCstr)
+	}
+
+	// The finalizer closes a device that is dropped without being closed,
+	// so a forgotten Close eventually releases the HID handle.
+	d := &cgoDevice{dev: devRet, path: t.Path}
+	runtime.SetFinalizer(d, (*cgoDevice).finalize)
+	return d, nil
+}
+
+func (d *cgoDevice) Close() {
+	runtime.SetFinalizer(d, nil)
+	if d.dev != nil {
+		C.tempered_close(d.dev)
+		d.dev = nil
+	}
+}
+
+// finalize closes d unless the library has been exited since, after which
+// the handle must not be touched.
+func (d *cgoDevice) finalize() {
+	if err := acquireLib(nativeBackend); err != nil {
+		return
+	}
+	defer libLock.RUnlock()
+
+	d.Close()
+}
+
+// sendCommand writes data as one report through a second hidapi handle on
+// the device, as libtempered keeps its own private.
+func (d *cgoDevice) sendCommand(data []byte) error {
+	if len(data) == 0 {
+		return &TemperedError{Op: OP_SEND_COMMAND, Message: "empty report", Err: ERR_COMMAND_FAILED}
+	}
+
+	cPath := C.CString(d.path)
+	defer C.free(unsafe.Pointer(cPath))
+	h := C.hid_open_path(cPath)
+	if h == nil {
+		return &TemperedError{Op: OP_SEND_COMMAND, Message: "cannot open " + d.path, Err: ERR_COMMAND_FAILED}
+	}
+	defer C.hid_close(h)
+
+	if C.hid_write(h, (*C.uchar)(unsafe.Pointer(&data[0])), C.size_t(len(data))) < 0 {
+		return &TemperedError{Op: OP_SEND_COMMAND, Message: "write to " + d.path + " failed", Err: ERR_COMMAND_FAILED}
+	}
+	return nil
+}
+
+func (d *cgoDevice) SensorCount() int {
+	return int(C.tempered_get_sensor_count(d.dev))
+}
+
+func (d *cgoDevice) SensorType(sensorNum int) TemperedSensorType {
+	return TemperedSensorType(C.tempered_get_sensor_type(d.dev, C.int(sensorNum)))
+}
+
+func (d *cgoDevice) ReadSensors() bool {
+	return bool(C.tempered_read_sensors(d.dev))
+}
+
+func (d *cgoDevice) Temperature(sensorNum int) (float64, bool) {
+	var cFloat C.float
+	retrOk := C.tempered_get_temperature(d.dev, C.int(sensorNum), &cFloat)
+	return float64(cFloat), bool(retrOk)
+}
+
+func (d *cgoDevice) Humidity(sensorNum int) (float64, bool) {
+	var cFloat C.float
+	retrOk := C.tempered_get_humidity(d.dev, C.int(sensorNum), &cFloat)
+	return float64(cFloat), bool(retrOk)
+}
+
+func (d *cgoDevice) readAll(count int) []sensorValues {
+	if count <= 0 {
+		return nil
+	}
+	types := make([]C.int, count)
+	temps := make([]C.float, count)
+	tempOk := make([]C.bool, count)
+	hums := make([]C.float, count)
+	humOk := make([]C.bool, count)
+	C.tempered_go_read_all(d.dev, C.int(count), &types[0], &temps[0], &tempOk[0], &hums[0], &humOk[0])
+
+	values := make([]sensorValues, count)
+	for n := range values {
+		values[n] = sensorValues{
+			sensorType:    TemperedSensorType(types[n]),
+			temperature:   float64(temps[n]),
+			temperatureOk: bool(tempOk[n]),
+			humidity:      float64(hums[n]),
+			humidityOk:    bool(humOk[n]),
+		}
+	}
+	return values
+}
@@ -0,0 +1,20 @@
+//go:build !cgo
+
+package temperedgo
+
+// Without cgo there is no libtempered, so the default backend is a
+// FakeBackend with no devices. Tests add virtual devices to it through
+// DefaultFake, so that they run without hardware or the C library.
+var nativeFake = &FakeBackend{}
+
+var nativeBackend Backend = nativeFake
+
+func CgoEnabled() bool {
+	return false
+}
+
+// DefaultFake returns the FakeBackend that is the default backend when the
+// package is built without cgo, or nil when libtempered is the default.
+func DefaultFake() *FakeBackend {
+	return nativeFake
+}
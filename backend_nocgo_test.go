@@ -0,0 +1,103 @@
+//go:build !cgo
+
+package temperedgo
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDefaultBackendIsFake(t *testing.T) {
+	fb := DefaultFake()
+	if fb == nil {
+		t.Fatal("DefaultFake() = nil in a !cgo build")
+	}
+	if CgoEnabled() || Available() {
+		t.Errorf("CgoEnabled() = %v, Available() = %v; want false, false", CgoEnabled(), Available())
+	}
+
+	fb.Lock()
+	fb.Devices = []*FakeDevice{{
+		Path:     "/dev/fake0",
+		TypeName: "TEMPerHUM",
+		Sensors:  []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_ALL}},
+		Script: [][]FakeSensor{
+			{{Type: TEMPERED_SENSOR_TYPE_ALL, Temperature: 20, Humidity: 40}},
+			{{Type: TEMPERED_SENSOR_TYPE_ALL, Temperature: 21, Humidity: 41}},
+		},
+	}}
+	fb.Unlock()
+	t.Cleanup(func() {
+		fb.Lock()
+		fb.Devices = nil
+		fb.Unlock()
+	})
+
+	tm := new(Tempered)
+	if err := tm.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	defer tm.Exit()
+
+	tds, err := tm.DeviceList()
+	if err != nil {
+		t.Fatalf("DeviceList: %v", err)
+	}
+	if len(tds) != 1 || tds[0].Path != "/dev/fake0" {
+		t.Fatalf("DeviceList = %v, want just /dev/fake0", tds)
+	}
+
+	td := &tds[0]
+	if err := td.Open(); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	for _, want := range []Celsius{20, 21, 21} {
+		if err := td.Update(); err != nil {
+			t.Fatalf("Update: %v", err)
+		}
+		if got, err := td.Temperature(0); err != nil || got != want {
+			t.Errorf("Temperature(0) = %v, %v; want %v, nil", got, err, want)
+		}
+	}
+	if err := td.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if n := fb.OpenHandles(); n != 0 {
+		t.Errorf("OpenHandles() = %d after Close, want 0", n)
+	}
+}
+
+func TestPackageReadAll(t *testing.T) {
+	fb := DefaultFake()
+	fb.Lock()
+	fb.Devices = []*FakeDevice{
+		{Path: "/dev/fake0", Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_TEMPERATURE, Temperature: 20}}},
+		{Path: "/dev/fake1", OpenErr: errors.New("unplugged")},
+	}
+	fb.Unlock()
+	t.Cleanup(func() {
+		fb.Lock()
+		fb.Devices = nil
+		fb.Unlock()
+	})
+
+	drs, err := ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if len(drs) != 2 {
+		t.Fatalf("ReadAll returned %d entries, want 2: %v", len(drs), drs)
+	}
+	if drs[0].Path != "/dev/fake0" || drs[0].Err != nil || drs[0].Temperature == nil || *drs[0].Temperature != 20 {
+		t.Errorf("first entry = %+v, want a 20°C reading from /dev/fake0", drs[0])
+	}
+	if drs[1].Path != "/dev/fake1" || !errors.Is(drs[1].Err, ERR_OPEN_FAILED) {
+		t.Errorf("second entry = %+v, want /dev/fake1 with ERR_OPEN_FAILED", drs[1])
+	}
+	if n := fb.OpenHandles(); n != 0 {
+		t.Errorf("OpenHandles() = %d after ReadAll, want 0", n)
+	}
+	if _, err := new(Tempered).DeviceList(); !errors.Is(err, ERR_NOT_INITED) {
+		t.Errorf("DeviceList after ReadAll = %v, want ERR_NOT_INITED", err)
+	}
+}
@@ -0,0 +1,81 @@
+package temperedgo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+type backgroundPoll struct {
+	cancel context.CancelFunc
+
+	lock     sync.Mutex
+	readings []Reading
+	time     time.Time
+}
+
+// StartBackgroundPoll reads the device every interval until ctx is done,
+// keeping the most recent successful result for LatestReading. Failed reads
+// leave the previous result in place. Starting a poll stops any earlier one
+// on the device, and LatestReading then reports only the new poll's
+// readings.
+func (t *TemperedDevice) StartBackgroundPoll(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return errors.New("tempered: poll interval must be positive")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	bg := &backgroundPoll{cancel: cancel}
+	t.mutex().Lock()
+	if t.bg != nil {
+		t.bg.cancel()
+	}
+	t.bg = bg
+	t.mutex().Unlock()
+
+	go func() {
+		defer cancel()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if rs, err := t.ReadAll(); err == nil {
+				bg.lock.Lock()
+				bg.readings = rs
+				bg.time = time.Now()
+				bg.lock.Unlock()
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// LatestReading returns the background poll's most recent reading of sensor
+// sensorNum and when it was taken. The bool is false if there is no such
+// reading yet.
+func (t *TemperedDevice) LatestReading(sensorNum int) (Reading, time.Time, bool) {
+	t.mutex().Lock()
+	bg := t.bg
+	t.mutex().Unlock()
+	if bg == nil {
+		return Reading{}, time.Time{}, false
+	}
+
+	bg.lock.Lock()
+	defer bg.lock.Unlock()
+
+	for _, r := range bg.readings {
+		if r.SensorNum == sensorNum {
+			return r, bg.time, true
+		}
+	}
+	return Reading{}, time.Time{}, false
+}
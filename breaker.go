@@ -0,0 +1,96 @@
+package temperedgo
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type CircuitState int
+
+const (
+	// CIRCUIT_STATE_CLOSED passes reads through to the device.
+	CIRCUIT_STATE_CLOSED CircuitState = iota
+	// CIRCUIT_STATE_OPEN fails reads with ERR_CIRCUIT_OPEN without touching
+	// the device.
+	CIRCUIT_STATE_OPEN
+	// CIRCUIT_STATE_HALF_OPEN lets a single probe read through once the
+	// cooldown has passed.
+	CIRCUIT_STATE_HALF_OPEN
+)
+
+func (cs CircuitState) String() string {
+	switch cs {
+	case CIRCUIT_STATE_CLOSED:
+		return "closed"
+	case CIRCUIT_STATE_OPEN:
+		return "open"
+	case CIRCUIT_STATE_HALF_OPEN:
+		return "half-open"
+	}
+	return fmt.Sprintf("CircuitState(%d)", int(cs))
+}
+
+// CircuitBreakerDevice wraps a device so that after Threshold consecutive
+// failed reads it stops reading it for Cooldown, failing immediately with
+// ERR_CIRCUIT_OPEN instead. After the cooldown one probe read is let
+// through: if it succeeds reads resume, otherwise the cooldown starts again.
+type CircuitBreakerDevice struct {
+	dev       DeviceReader
+	threshold int
+	cooldown  time.Duration
+
+	lock      sync.Mutex
+	state     CircuitState
+	failures  int
+	openUntil time.Time
+}
+
+func NewCircuitBreakerDevice(dev DeviceReader, threshold int, cooldown time.Duration) *CircuitBreakerDevice {
+	return &CircuitBreakerDevice{dev: dev, threshold: threshold, cooldown: cooldown}
+}
+
+// State reports the breaker's state, and while it is open, when the next
+// probe read will be allowed.
+func (cb *CircuitBreakerDevice) State() (CircuitState, time.Time) {
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+
+	if cb.state == CIRCUIT_STATE_OPEN {
+		return cb.state, cb.openUntil
+	}
+	return cb.state, time.Time{}
+}
+
+func (cb *CircuitBreakerDevice) ReadAll() ([]Reading, error) {
+	cb.lock.Lock()
+	switch cb.state {
+	case CIRCUIT_STATE_OPEN:
+		if time.Now().Before(cb.openUntil) {
+			cb.lock.Unlock()
+			return nil, ERR_CIRCUIT_OPEN
+		}
+		cb.state = CIRCUIT_STATE_HALF_OPEN
+	case CIRCUIT_STATE_HALF_OPEN:
+		// Another probe is already in flight.
+		cb.lock.Unlock()
+		return nil, ERR_CIRCUIT_OPEN
+	}
+	cb.lock.Unlock()
+
+	rs, err := cb.dev.ReadAll()
+
+	cb.lock.Lock()
+	defer cb.lock.Unlock()
+	if err == nil {
+		cb.state = CIRCUIT_STATE_CLOSED
+		cb.failures = 0
+		return rs, nil
+	}
+	cb.failures++
+	if cb.state == CIRCUIT_STATE_HALF_OPEN || cb.failures >= cb.threshold {
+		cb.state = CIRCUIT_STATE_OPEN
+		cb.openUntil = time.Now().Add(cb.cooldown)
+	}
+	return nil, err
+}
@@ -0,0 +1,187 @@
+package temperedgo
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+type BroadcastPolicy int
+
+const (
+	// BROADCAST_POLICY_DROP skips a subscriber whose buffer is full, so a
+	// slow subscriber misses readings instead of holding up the rest.
+	BROADCAST_POLICY_DROP BroadcastPolicy = iota
+	// BROADCAST_POLICY_BLOCK waits for every subscriber to accept each
+	// reading, so the slowest subscriber paces the source.
+	BROADCAST_POLICY_BLOCK
+)
+
+type subscriber struct {
+	ch       chan Reading
+	gone     chan struct{}
+	goneOnce sync.Once
+
+	// sendLock is held while delivering to the subscriber and while closing
+	// ch, so that ch is never closed under a send. It is per subscriber so
+	// that a delivery stuck on one never holds up closing another.
+	sendLock sync.Mutex
+
+	delivered atomic.Uint64
+	dropped   atomic.Uint64
+}
+
+func (s *subscriber) leave() {
+	s.goneOnce.Do(func() { close(s.gone) })
+}
+
+// close stops delivery to s and closes its channel, waiting only for a
+// delivery to s itself, which leaving unblocks.
+func (s *subscriber) close() {
+	s.leave()
+	s.sendLock.Lock()
+	close(s.ch)
+	s.sendLock.Unlock()
+}
+
+// Broadcaster fans readings from a single source channel out to any number
+// of subscribers. When the source is closed, every subscriber channel is
+// closed too.
+type Broadcaster struct {
+	policy BroadcastPolicy
+	buffer int
+
+	produced atomic.Uint64
+
+	subsLock sync.Mutex
+	subs     map[<-chan Reading]*subscriber
+	closed   bool
+}
+
+// NewBroadcaster starts fanning out src. Each subscriber channel has room for
+// buffer readings before policy applies. To fan out a Poll, pass its channel
+// through PollReadings.
+func NewBroadcaster(src <-chan Reading, policy BroadcastPolicy, buffer int) *Broadcaster {
+	b := &Broadcaster{
+		policy: policy,
+		buffer: buffer,
+		subs:   make(map[<-chan Reading]*subscriber),
+	}
+	go b.run(src)
+	return b
+}
+
+func (b *Broadcaster) run(src <-chan Reading) {
+	for r := range src {
+		b.produced.Add(1)
+		b.subsLock.Lock()
+		subs := make([]*subscriber, 0, len(b.subs))
+		for _, s := range b.subs {
+			subs = append(subs, s)
+		}
+		b.subsLock.Unlock()
+
+		for _, s := range subs {
+			b.deliver(s, r)
+		}
+	}
+
+	b.subsLock.Lock()
+	b.closed = true
+	subs := b.subs
+	b.subs = make(map[<-chan Reading]*subscriber)
+	b.subsLock.Unlock()
+
+	for _, s := range subs {
+		s.close()
+	}
+}
+
+// deliver sends r to s according to the policy.
+func (b *Broadcaster) deliver(s *subscriber, r Reading) {
+	s.sendLock.Lock()
+	defer s.sendLock.Unlock()
+
+	select {
+	case <-s.gone:
+		return
+	default:
+	}
+
+	if b.policy == BROADCAST_POLICY_BLOCK {
+		select {
+		case s.ch <- r:
+			s.delivered.Add(1)
+		case <-s.gone:
+		}
+		return
+	}
+
+	select {
+	case s.ch <- r:
+		s.delivered.Add(1)
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// DeliveryStats counts readings handed to one consumer and those it missed.
+type DeliveryStats struct {
+	Delivered uint64
+	Dropped   uint64
+}
+
+// BroadcastStats counts the readings a Broadcaster has taken from its
+// source and, for each current subscriber, how many it was sent or missed
+// because its buffer was full.
+type BroadcastStats struct {
+	Produced    uint64
+	Subscribers map[<-chan Reading]DeliveryStats
+}
+
+func (b *Broadcaster) Stats() BroadcastStats {
+	b.subsLock.Lock()
+	defer b.subsLock.Unlock()
+
+	stats := BroadcastStats{
+		Produced:    b.produced.Load(),
+		Subscribers: make(map[<-chan Reading]DeliveryStats, len(b.subs)),
+	}
+	for ch, s := range b.subs {
+		stats.Subscribers[ch] = DeliveryStats{
+			Delivered: s.delivered.Load(),
+			Dropped:   s.dropped.Load(),
+		}
+	}
+	return stats
+}
+
+// Subscribe returns a channel receiving every subsequent reading. If the
+// source has already been closed, the channel is returned closed.
+func (b *Broadcaster) Subscribe() <-chan Reading {
+	s := &subscriber{
+		ch:   make(chan Reading, b.buffer),
+		gone: make(chan struct{}),
+	}
+
+	b.subsLock.Lock()
+	defer b.subsLock.Unlock()
+
+	if b.closed {
+		close(s.ch)
+		return s.ch
+	}
+	b.subs[s.ch] = s
+	return s.ch
+}
+
+// Unsubscribe stops delivery to ch and closes it. It doesn't wait for a
+// delivery blocked on another subscriber.
+func (b *Broadcaster) Unsubscribe(ch <-chan Reading) {
+	b.subsLock.Lock()
+	s, ok := b.subs[ch]
+	delete(b.subs, ch)
+	b.subsLock.Unlock()
+	if ok {
+		s.close()
+	}
+}
@@ -0,0 +1,144 @@
+package temperedgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBroadcasterUnsubscribeWhileBlocked(t *testing.T) {
+	src := make(chan Reading)
+	defer close(src)
+	b := NewBroadcaster(src, BROADCAST_POLICY_BLOCK, 0)
+	stalled := b.Subscribe()
+	other := b.Subscribe()
+
+	// other is drained but nobody reads stalled, so delivering the reading
+	// blocks on stalled.
+	src <- Reading{}
+	go func() {
+		for range other {
+		}
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		b.Unsubscribe(other)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Unsubscribe waited on a delivery stuck on another subscriber")
+	}
+
+	// Unsubscribing the stalled subscriber frees the delivery.
+	b.Unsubscribe(stalled)
+	select {
+	case src <- Reading{}:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the source is still blocked after the stalled subscriber left")
+	}
+}
+
+func TestBroadcasterBlockDeliversEverything(t *testing.T) {
+	src := make(chan Reading)
+	b := NewBroadcaster(src, BROADCAST_POLICY_BLOCK, 0)
+	subs := []<-chan Reading{b.Subscribe(), b.Subscribe()}
+
+	got := make([][]int, len(subs))
+	done := make(chan struct{})
+	for n, ch := range subs {
+		go func() {
+			for r := range ch {
+				got[n] = append(got[n], r.SensorNum)
+			}
+			done <- struct{}{}
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		src <- Reading{SensorNum: i}
+	}
+	close(src)
+	for range subs {
+		<-done
+	}
+
+	for n := range subs {
+		if len(got[n]) != 100 {
+			t.Fatalf("subscriber %d got %d readings, want 100", n, len(got[n]))
+		}
+		for i, sensorNum := range got[n] {
+			if sensorNum != i {
+				t.Fatalf("subscriber %d got reading %d as number %d", n, sensorNum, i)
+			}
+		}
+	}
+
+	stats := b.Stats()
+	if stats.Produced != 100 {
+		t.Errorf("Stats().Produced = %d, want 100", stats.Produced)
+	}
+	// Closing the source closed and dropped every subscriber.
+	if len(stats.Subscribers) != 0 {
+		t.Errorf("Stats() still has %d subscribers after the source closed", len(stats.Subscribers))
+	}
+	if _, ok := <-b.Subscribe(); ok {
+		t.Error("Subscribe after the source closed returned an open channel")
+	}
+}
+
+func TestBroadcasterDropCountsMissedReadings(t *testing.T) {
+	src := make(chan Reading)
+	defer close(src)
+	b := NewBroadcaster(src, BROADCAST_POLICY_DROP, 2)
+	slow := b.Subscribe()
+
+	// Nobody reads slow, so after its buffer of two fills the rest are
+	// dropped rather than holding up the source.
+	for i := 0; i < 5; i++ {
+		select {
+		case src <- Reading{}:
+		case <-time.After(5 * time.Second):
+			t.Fatal("a full subscriber held up the source")
+		}
+	}
+	waitFor(t, "all five readings to be handled", func() bool {
+		st := b.Stats().Subscribers[slow]
+		return st.Delivered+st.Dropped == 5
+	})
+	if st := b.Stats().Subscribers[slow]; st.Delivered != 2 || st.Dropped != 3 {
+		t.Errorf("slow subscriber stats = %+v, want 2 delivered and 3 dropped", st)
+	}
+	if got := b.Stats().Produced; got != 5 {
+		t.Errorf("Stats().Produced = %d, want 5", got)
+	}
+
+	b.Unsubscribe(slow)
+	n := 0
+	for range slow {
+		n++
+	}
+	if n != 2 {
+		t.Errorf("drained %d buffered readings after Unsubscribe, want 2", n)
+	}
+	if _, ok := b.Stats().Subscribers[slow]; ok {
+		t.Error("Stats() still lists an unsubscribed channel")
+	}
+}
+
+func TestPollReadings(t *testing.T) {
+	src := make(chan PollResult, 3)
+	src <- PollResult{Readings: []Reading{{SensorNum: 0}, {SensorNum: 1}}}
+	src <- PollResult{Err: ERR_FAILED_UPDATE}
+	src <- PollResult{Readings: []Reading{{SensorNum: 2}}}
+	close(src)
+
+	var got []int
+	for r := range PollReadings(src) {
+		got = append(got, r.SensorNum)
+	}
+	if len(got) != 3 || got[0] != 0 || got[1] != 1 || got[2] != 2 {
+		t.Errorf("PollReadings gave sensors %v, want [0 1 2]", got)
+	}
+}
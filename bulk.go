@@ -0,0 +1,263 @@
+package temperedgo
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type ErrorMode int
+
+const (
+	// ERROR_MODE_COLLECT carries on past a failing device, reporting its
+	// error alongside the results of the others. It is the default.
+	ERROR_MODE_COLLECT ErrorMode = iota
+	// ERROR_MODE_FAIL_FAST stops at the first failing device and returns
+	// its error.
+	ERROR_MODE_FAIL_FAST
+)
+
+// DeviceReading is a Reading tagged with the device it came from. When the
+// device could not be opened or read, Err is set and Reading is empty.
+type DeviceReading struct {
+	DeviceID string
+	Path     string
+	Reading
+	Err error
+}
+
+func readDevice(td TemperedDevice) ([]Reading, error) {
+	if err := td.Open(); err != nil {
+		return nil, err
+	}
+	defer td.Close()
+
+	return td.ReadAll()
+}
+
+// Scan opens, reads and closes every device, returning the readings keyed by
+// device ID. In ERROR_MODE_COLLECT the devices that failed are missing from
+// the map and their errors are joined into the returned error.
+func (t *Tempered) Scan() (map[string][]Reading, error) {
+	tds, err := t.DeviceList()
+	if err != nil {
+		return nil, err
+	}
+
+	readings := make(map[string][]Reading, len(tds))
+	var errs []error
+	for _, td := range tds {
+		rs, err := readDevice(td)
+		if err != nil {
+			if t.ErrorMode == ERROR_MODE_FAIL_FAST {
+				return nil, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		readings[td.ID()] = rs
+	}
+
+	return readings, errors.Join(errs...)
+}
+
+// OpenAll opens every device. In ERROR_MODE_COLLECT the devices that failed
+// to open are left out and their errors are joined into the returned error;
+// in ERROR_MODE_FAIL_FAST any devices already opened are closed again before
+// returning the error.
+func (t *Tempered) OpenAll() ([]*TemperedDevice, error) {
+	tds, err := t.DeviceList()
+	if err != nil {
+		return nil, err
+	}
+
+	devs := make([]*TemperedDevice, 0, len(tds))
+	var errs []error
+	for _, td := range tds {
+		dev := td
+		if err := dev.Open(); err != nil {
+			if t.ErrorMode == ERROR_MODE_FAIL_FAST {
+				for _, opened := range devs {
+					opened.Close()
+				}
+				return nil, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		devs = append(devs, &dev)
+	}
+
+	return devs, errors.Join(errs...)
+}
+
+// ReadAllFlat opens and reads every device, returning one entry per sensor.
+// In ERROR_MODE_COLLECT a device that fails contributes a single entry
+// carrying its error rather than aborting the scan; in ERROR_MODE_FAIL_FAST
+// the entries read so far are returned along with the error.
+func (t *Tempered) ReadAllFlat() ([]DeviceReading, error) {
+	tds, err := t.DeviceList()
+	if err != nil {
+		return nil, err
+	}
+
+	drs := []DeviceReading{}
+	for _, td := range tds {
+		rs, err := readDevice(td)
+		if err != nil {
+			if t.ErrorMode == ERROR_MODE_FAIL_FAST {
+				return drs, err
+			}
+			drs = append(drs, DeviceReading{DeviceID: td.ID(), Path: td.Path, Err: err})
+			continue
+		}
+		for _, r := range rs {
+			drs = append(drs, DeviceReading{DeviceID: td.ID(), Path: td.Path, Reading: r})
+		}
+	}
+
+	return drs, nil
+}
+
+// SnapshotAll updates every device back-to-back and then reads them all,
+// stamping every reading with one capture time: the midpoint of the
+// updates. The skew between the first and last device is roughly one update
+// per device (each is a USB round trip, typically a few tens of
+// milliseconds), so the capture time is within half that of each device's
+// true sample time. Readings are keyed by device ID; with ERROR_MODE_COLLECT
+// devices that fail are left out and their errors joined.
+func (t *Tempered) SnapshotAll(devices []*TemperedDevice) (time.Time, map[string][]Reading, error) {
+	// Wait out any warm-up first so that it doesn't add to the skew.
+	for _, td := range devices {
+		td.waitWarmUp()
+	}
+
+	if err := acquireLib(t.backendOrDefault()); err != nil {
+		return time.Time{}, nil, err
+	}
+	defer libLock.RUnlock()
+
+	var errs []error
+	updated := make([]*TemperedDevice, 0, len(devices))
+	start := time.Now()
+	for _, td := range devices {
+		td.lockDevice()
+		err := td.update()
+		td.unlockDevice()
+		if err != nil {
+			if t.ErrorMode == ERROR_MODE_FAIL_FAST {
+				return time.Time{}, nil, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		updated = append(updated, td)
+	}
+	captured := start.Add(time.Since(start) / 2)
+
+	// One wait for the longest ReadDelay covers every device.
+	var delay time.Duration
+	for _, td := range updated {
+		if td.ReadDelay > delay {
+			delay = td.ReadDelay
+		}
+	}
+	time.Sleep(delay)
+
+	readings := make(map[string][]Reading, len(updated))
+	for _, td := range updated {
+		td.lockDevice()
+		rs, err := td.readCached(captured)
+		td.unlockDevice()
+		if err != nil {
+			if t.ErrorMode == ERROR_MODE_FAIL_FAST {
+				return time.Time{}, nil, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		readings[td.ID()] = rs
+	}
+	return captured, readings, errors.Join(errs...)
+}
+
+// ScanWithTimeout is Scan with each device's open and read given perDevice
+// to finish, so that one hung device can't hold up the rest. A device that
+// runs out of time is skipped and its error, wrapping ERR_TIMEOUT, recorded
+// as Scan records failures; its abandoned read finishes, and the device is
+// closed, in the background.
+func (t *Tempered) ScanWithTimeout(perDevice time.Duration) (map[string][]Reading, error) {
+	tds, err := t.DeviceList()
+	if err != nil {
+		return nil, err
+	}
+
+	readings := make(map[string][]Reading, len(tds))
+	var errs []error
+	for _, td := range tds {
+		rs, err := readDeviceTimeout(td, perDevice)
+		if err != nil {
+			if t.ErrorMode == ERROR_MODE_FAIL_FAST {
+				return nil, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		readings[td.ID()] = rs
+	}
+
+	return readings, errors.Join(errs...)
+}
+
+func readDeviceTimeout(td TemperedDevice, timeout time.Duration) ([]Reading, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	dev := &td
+	if err := dev.OpenContext(ctx); err != nil {
+		if ctx.Err() != nil {
+			return nil, dev.deviceError(ERR_TIMEOUT)
+		}
+		return nil, err
+	}
+
+	type result struct {
+		rs  []Reading
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		defer dev.Close()
+		rs, err := dev.ReadAll()
+		done <- result{rs, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, dev.deviceError(ERR_TIMEOUT)
+	case res := <-done:
+		return res.rs, res.err
+	}
+}
+
+// ReadAll is a one-shot scan for tools that read everything once and exit:
+// it inits the library, opens and reads every device, and closes each one
+// and exits again before returning, even on failure. The readings are as
+// ReadAllFlat returns them, one DeviceReading per sensor; a device that
+// fails doesn't stop the scan, but contributes a single DeviceReading
+// carrying its error. The returned error is set only if the library could
+// not be inited, devices could not be enumerated, or the library could not
+// be exited.
+func ReadAll() (drs []DeviceReading, err error) {
+	t := new(Tempered)
+	if err := t.Init(); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if exitErr := t.Exit(); exitErr != nil && err == nil {
+			err = exitErr
+		}
+	}()
+
+	return t.ReadAllFlat()
+}
@@ -0,0 +1,121 @@
+package temperedgo
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// calLock guards every device's calibration offsets, so that they change
+// together: a read in progress sees either the old pair or the new one.
+var calLock sync.RWMutex
+
+// SetCalibration sets offsets added to every temperature and humidity the
+// device reports from now on. They replace any offsets set before, and may
+// be changed while the device is being read; each read applies one
+// consistent pair throughout.
+func (t *TemperedDevice) SetCalibration(tempOffset, humidityOffset float64) {
+	calLock.Lock()
+	defer calLock.Unlock()
+
+	t.cal = calibration{Temperature: tempOffset, Humidity: humidityOffset}
+}
+
+// Calibration returns the offsets set by SetCalibration.
+func (t *TemperedDevice) Calibration() (tempOffset, humidityOffset float64) {
+	cal := t.calibration()
+	return cal.Temperature, cal.Humidity
+}
+
+func (t *TemperedDevice) calibration() calibration {
+	calLock.RLock()
+	defer calLock.RUnlock()
+
+	return t.cal
+}
+
+// CalibrationStore persists calibration offsets by device ID.
+type CalibrationStore interface {
+	Load(deviceID string) (tempOffset, humidityOffset float64, ok bool)
+	Save(deviceID string, tempOffset, humidityOffset float64) error
+}
+
+// calibration is a pair of offsets, as stored by JSONCalibrationStore.
+type calibration struct {
+	Temperature float64 `json:"temperature"`
+	Humidity    float64 `json:"humidity"`
+}
+
+// JSONCalibrationStore is a CalibrationStore kept in a JSON file mapping
+// device IDs to offsets. A missing file is treated as empty.
+type JSONCalibrationStore struct {
+	Path string
+
+	lock sync.Mutex
+}
+
+func NewJSONCalibrationStore(path string) *JSONCalibrationStore {
+	return &JSONCalibrationStore{Path: path}
+}
+
+func (s *JSONCalibrationStore) read() (map[string]calibration, error) {
+	cals := map[string]calibration{}
+	data, err := os.ReadFile(s.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cals, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &cals); err != nil {
+		return nil, err
+	}
+	return cals, nil
+}
+
+// Load reports false if the file has no entry for deviceID or cannot be
+// read.
+func (s *JSONCalibrationStore) Load(deviceID string) (tempOffset, humidityOffset float64, ok bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	cals, err := s.read()
+	if err != nil {
+		return 0, 0, false
+	}
+	cal, ok := cals[deviceID]
+	return cal.Temperature, cal.Humidity, ok
+}
+
+// Save rewrites the file with deviceID's offsets updated, replacing it
+// atomically so a crash part-way through leaves the old contents.
+func (s *JSONCalibrationStore) Save(deviceID string, tempOffset, humidityOffset float64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	cals, err := s.read()
+	if err != nil {
+		return err
+	}
+	cals[deviceID] = calibration{Temperature: tempOffset, Humidity: humidityOffset}
+
+	data, err := json.MarshalIndent(cals, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), ".calibration-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
@@ -0,0 +1,57 @@
+package temperedgo
+
+import (
+	"context"
+	"sync"
+)
+
+type updateCall struct {
+	done chan struct{}
+	err  error
+}
+
+// updateCalls holds the update in flight on each native handle, keyed by
+// the handle so that copies of a TemperedDevice coalesce too.
+var (
+	updateCallsLock sync.Mutex
+	updateCalls     = map[DeviceHandle]*updateCall{}
+)
+
+// coalescedUpdate is updateContext, joining one already in flight on the
+// same handle if there is one. Callers that join rather than lead don't have
+// their own Age refreshed, and share the leader's error even if it came from
+// the leader's ctx. The caller must hold libLock.
+func (t *TemperedDevice) coalescedUpdate(ctx context.Context) error {
+	t.mutex().Lock()
+	dev := t.dev
+	t.mutex().Unlock()
+	if dev == nil {
+		t.lockDevice()
+		defer t.unlockDevice()
+		return t.updateContext(ctx)
+	}
+
+	updateCallsLock.Lock()
+	if c, ok := updateCalls[dev]; ok {
+		updateCallsLock.Unlock()
+		select {
+		case <-c.done:
+			return c.err
+		case <-ctx.Done():
+			return t.deviceError(ctx.Err())
+		}
+	}
+	c := &updateCall{done: make(chan struct{})}
+	updateCalls[dev] = c
+	updateCallsLock.Unlock()
+
+	t.lockDevice()
+	c.err = t.updateContext(ctx)
+	t.unlockDevice()
+
+	updateCallsLock.Lock()
+	delete(updateCalls, dev)
+	updateCallsLock.Unlock()
+	close(c.done)
+	return c.err
+}
@@ -0,0 +1,27 @@
+package temperedgo
+
+import (
+	"time"
+)
+
+// Columns splits readings into parallel column slices for columnar
+// ingestion, such as building Arrow record batches. All slices have one
+// entry per reading. A temperature or humidity the reading lacks is stored
+// as zero; validMask has the sensor type bit set for each column that holds
+// a measurement, as in ValueReading.Valid.
+func Columns(rs []Reading) (times []time.Time, devs []string, temps []float64, hums []float64, validMask []TemperedSensorType) {
+	times = make([]time.Time, len(rs))
+	devs = make([]string, len(rs))
+	temps = make([]float64, len(rs))
+	hums = make([]float64, len(rs))
+	validMask = make([]TemperedSensorType, len(rs))
+	for n, r := range rs {
+		vr := r.Values()
+		times[n] = vr.Time
+		devs[n] = vr.DeviceID
+		temps[n] = vr.Temperature
+		hums[n] = vr.Humidity
+		validMask[n] = vr.Valid
+	}
+	return times, devs, temps, hums, validMask
+}
@@ -0,0 +1,111 @@
+package temperedgo
+
+import (
+	"math"
+)
+
+const (
+	DEFAULT_METABOLIC_RATE = 1.2 // met, seated office work
+	DEFAULT_CLOTHING       = 0.5 // clo, light summer clothing
+	DEFAULT_AIR_VELOCITY   = 0.1 // m/s, still indoor air
+)
+
+// PMVOptions are the inputs to the Fanger comfort model beyond temperature
+// and humidity. A zero MetabolicRate, Clothing or AirVelocity means the
+// matching DEFAULT_ value, so the zero PMVOptions is DefaultPMVOptions.
+// MeanRadiantTemperature, in degrees Celsius, defaults to the air
+// temperature when nil.
+type PMVOptions struct {
+	MetabolicRate          float64
+	Clothing               float64
+	AirVelocity            float64
+	ExternalWork           float64
+	MeanRadiantTemperature *float64
+}
+
+func DefaultPMVOptions() PMVOptions {
+	return PMVOptions{
+		MetabolicRate: DEFAULT_METABOLIC_RATE,
+		Clothing:      DEFAULT_CLOTHING,
+		AirVelocity:   DEFAULT_AIR_VELOCITY,
+	}
+}
+
+// PMV is Fanger's Predicted Mean Vote, as specified in ISO 7730, for an air
+// temperature in degrees Celsius and a relative humidity in percent. It
+// runs from -3 (cold) through 0 (neutral) to +3 (hot). It returns NaN if
+// the clothing surface temperature iteration fails to converge, which only
+// happens far outside the model's valid range. The vapour pressure comes
+// from the formula set with SetSaturationVaporPressureFunc.
+func PMV(temp, humidity float64, opts PMVOptions) float64 {
+	def := DefaultPMVOptions()
+	if opts.MetabolicRate == 0 {
+		opts.MetabolicRate = def.MetabolicRate
+	}
+	if opts.Clothing == 0 {
+		opts.Clothing = def.Clothing
+	}
+	if opts.AirVelocity == 0 {
+		opts.AirVelocity = def.AirVelocity
+	}
+
+	ta := temp
+	tr := ta
+	if opts.MeanRadiantTemperature != nil {
+		tr = *opts.MeanRadiantTemperature
+	}
+
+	// Water vapour partial pressure, in Pa: humidity percent of the hPa
+	// saturation pressure.
+	svp, _ := saturationVaporPressure()
+	pa := humidity * svp(ta)
+
+	icl := 0.155 * opts.Clothing
+	m := opts.MetabolicRate * 58.15
+	mw := m - opts.ExternalWork*58.15
+
+	fcl := 1.05 + 0.645*icl
+	if icl <= 0.078 {
+		fcl = 1 + 1.29*icl
+	}
+
+	hcf := 12.1 * math.Sqrt(opts.AirVelocity)
+	taa := ta + 273
+	tra := tr + 273
+
+	// Iterate for the clothing surface temperature.
+	tcla := taa + (35.5-ta)/(3.5*icl+0.1)
+	p1 := icl * fcl
+	p2 := p1 * 3.96
+	p3 := p1 * 100
+	p4 := p1 * taa
+	p5 := 308.7 - 0.028*mw + p2*math.Pow(tra/100, 4)
+	xn := tcla / 100
+	xf := tcla / 50
+	var hc float64
+	for n := 0; math.Abs(xn-xf) > 0.00015; n++ {
+		if n > 150 {
+			return math.NaN()
+		}
+		xf = (xf + xn) / 2
+		hc = math.Max(hcf, 2.38*math.Pow(math.Abs(100*xf-taa), 0.25))
+		xn = (p5 + p4*hc - p2*math.Pow(xf, 4)) / (100 + p3*hc)
+	}
+	hc = math.Max(hcf, 2.38*math.Pow(math.Abs(100*xn-taa), 0.25))
+	tcl := 100*xn - 273
+
+	// Heat losses: skin diffusion, sweating, latent and dry respiration,
+	// radiation and convection.
+	hl1 := 3.05 * 0.001 * (5733 - 6.99*mw - pa)
+	hl2 := 0.0
+	if mw > 58.15 {
+		hl2 = 0.42 * (mw - 58.15)
+	}
+	hl3 := 1.7e-5 * m * (5867 - pa)
+	hl4 := 0.0014 * m * (34 - ta)
+	hl5 := 3.96 * fcl * (math.Pow(xn, 4) - math.Pow(tra/100, 4))
+	hl6 := fcl * hc * (tcl - ta)
+
+	ts := 0.303*math.Exp(-0.036*m) + 0.028
+	return ts * (mw - hl1 - hl2 - hl3 - hl4 - hl5 - hl6)
+}
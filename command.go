@@ -0,0 +1,59 @@
+package temperedgo
+
+// SendCommand writes a raw report to the device. What the bytes mean is
+// entirely model-specific; a wrong command can leave a device misbehaving
+// until it is unplugged, so this is only for callers who know their
+// hardware. data goes to hidapi's hid_write as it is, so its first byte is
+// the report ID, 0 for devices without numbered reports. libtempered has no
+// raw write, so the cgo backend opens a second hidapi handle on the device
+// for each command. A failed write wraps ERR_COMMAND_FAILED.
+func (t *TemperedDevice) SendCommand(data []byte) error {
+	if err := acquireLib(t.backendOrDefault()); err != nil {
+		return t.deviceError(err)
+	}
+	defer libLock.RUnlock()
+	t.lockDevice()
+	defer t.unlockDevice()
+
+	if t.dev == nil {
+		return t.deviceError(ERR_NOT_OPEN)
+	}
+	cs, ok := t.dev.(commandSender)
+	if !ok {
+		return t.deviceError(ERR_NOT_SUPPORTED)
+	}
+	if err := cs.sendCommand(data); err != nil {
+		return t.deviceError(err)
+	}
+	return nil
+}
+
+func (t *TemperedDevice) stringDescriptor(get func(stringDescriptor) string) (string, error) {
+	if err := acquireLib(t.backendOrDefault()); err != nil {
+		return "", t.deviceError(err)
+	}
+	defer libLock.RUnlock()
+	t.lockDevice()
+	defer t.unlockDevice()
+
+	if t.dev == nil {
+		return "", t.deviceError(ERR_NOT_OPEN)
+	}
+	sd, ok := t.dev.(stringDescriptor)
+	if !ok {
+		return "", t.deviceError(ERR_NOT_SUPPORTED)
+	}
+	return get(sd), nil
+}
+
+// Manufacturer returns the USB manufacturer string of the open device.
+// libtempered keeps its HID handle private, so with the cgo backend this
+// returns ERR_NOT_SUPPORTED.
+func (t *TemperedDevice) Manufacturer() (string, error) {
+	return t.stringDescriptor(stringDescriptor.manufacturer)
+}
+
+// Product is Manufacturer for the USB product string.
+func (t *TemperedDevice) Product() (string, error) {
+	return t.stringDescriptor(stringDescriptor.product)
+}
@@ -0,0 +1,38 @@
+package temperedgo
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestSendCommand(t *testing.T) {
+	fb := &FakeBackend{Devices: []*FakeDevice{{
+		Path:    "/dev/fake0",
+		Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_TEMPERATURE}},
+	}}}
+	td := openFake(t, fb)
+
+	cmd := []byte{0, 0x01, 0x80, 0x33}
+	if err := td.SendCommand(cmd); err != nil {
+		t.Fatalf("SendCommand: %v", err)
+	}
+	cmd[1] = 0xff
+	fb.Lock()
+	sent := fb.Devices[0].Commands
+	fb.Devices[0].Disconnected = true
+	fb.Unlock()
+	if len(sent) != 1 || !bytes.Equal(sent[0], []byte{0, 0x01, 0x80, 0x33}) {
+		t.Errorf("device received %x, want one copy of 00018033", sent)
+	}
+
+	var te *TemperedError
+	if err := td.SendCommand(cmd); !errors.Is(err, ERR_COMMAND_FAILED) || !errors.As(err, &te) || te.Op != OP_SEND_COMMAND {
+		t.Errorf("SendCommand to a disconnected device = %v, want an OP_SEND_COMMAND ERR_COMMAND_FAILED", err)
+	}
+
+	td.Close()
+	if err := td.SendCommand(cmd); !errors.Is(err, ERR_NOT_OPEN) {
+		t.Errorf("SendCommand after Close = %v, want ERR_NOT_OPEN", err)
+	}
+}
@@ -0,0 +1,118 @@
+package temperedgo
+
+import (
+	"math"
+	"time"
+)
+
+// Comparison summarises how closely two series of values agree. Differences
+// are the first sensor's value minus the second's. Correlation is the
+// Pearson coefficient, and NaN if either series is constant.
+type Comparison struct {
+	Samples        int
+	MeanDifference float64
+	MaxDifference  float64
+	Correlation    float64
+}
+
+// CompareResult holds a Comparison for each measurement both sensors
+// support; the others are nil.
+type CompareResult struct {
+	Temperature *Comparison
+	Humidity    *Comparison
+}
+
+// CompareSensors reads a and b samples times, interval apart, updating both
+// devices before each sample, and compares the values. MaxDifference is the
+// largest absolute difference.
+func CompareSensors(a, b *TemperedSensor, samples int, interval time.Duration) (CompareResult, error) {
+	if samples < 1 {
+		samples = 1
+	}
+	withTemp := a.TypeMask.IsType(TEMPERED_SENSOR_TYPE_TEMPERATURE) && b.TypeMask.IsType(TEMPERED_SENSOR_TYPE_TEMPERATURE)
+	withHum := a.TypeMask.IsType(TEMPERED_SENSOR_TYPE_HUMIDITY) && b.TypeMask.IsType(TEMPERED_SENSOR_TYPE_HUMIDITY)
+	if !withTemp && !withHum {
+		return CompareResult{}, ERR_UNSUPPORTED_MEASUREMENT
+	}
+
+	var aTemps, bTemps, aHums, bHums []float64
+	for n := 0; n < samples; n++ {
+		if n > 0 {
+			time.Sleep(interval)
+		}
+		if err := a.device.Update(); err != nil {
+			return CompareResult{}, err
+		}
+		if b.device != a.device {
+			if err := b.device.Update(); err != nil {
+				return CompareResult{}, err
+			}
+		}
+
+		if withTemp {
+			at, err := a.Temperature()
+			if err != nil {
+				return CompareResult{}, err
+			}
+			bt, err := b.Temperature()
+			if err != nil {
+				return CompareResult{}, err
+			}
+			aTemps = append(aTemps, at.Float64())
+			bTemps = append(bTemps, bt.Float64())
+		}
+		if withHum {
+			ah, err := a.Humidity()
+			if err != nil {
+				return CompareResult{}, err
+			}
+			bh, err := b.Humidity()
+			if err != nil {
+				return CompareResult{}, err
+			}
+			aHums = append(aHums, ah)
+			bHums = append(bHums, bh)
+		}
+	}
+
+	var res CompareResult
+	if withTemp {
+		c := compareSeries(aTemps, bTemps)
+		res.Temperature = &c
+	}
+	if withHum {
+		c := compareSeries(aHums, bHums)
+		res.Humidity = &c
+	}
+	return res, nil
+}
+
+func compareSeries(xs, ys []float64) Comparison {
+	n := float64(len(xs))
+	var sumX, sumY, sumDiff, maxDiff float64
+	for i := range xs {
+		d := xs[i] - ys[i]
+		sumX += xs[i]
+		sumY += ys[i]
+		sumDiff += d
+		if math.Abs(d) > maxDiff {
+			maxDiff = math.Abs(d)
+		}
+	}
+
+	meanX, meanY := sumX/n, sumY/n
+	var cov, varX, varY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+
+	return Comparison{
+		Samples:        len(xs),
+		MeanDifference: sumDiff / n,
+		MaxDifference:  maxDiff,
+		Correlation:    cov / math.Sqrt(varX*varY),
+	}
+}
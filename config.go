@@ -0,0 +1,121 @@
+package temperedgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// exportedConfig is the JSON form of ExportConfig.
+type exportedConfig struct {
+	ErrorMode ErrorMode                 `json:"error_mode"`
+	Quirks    []exportedQuirk           `json:"quirks"`
+	Accuracy  map[string]AccuracySpec   `json:"accuracy"`
+	Devices   map[string]exportedDevice `json:"devices"`
+}
+
+type exportedQuirk struct {
+	VendorId  uint   `json:"vendor_id"`
+	ProductId uint   `json:"product_id"`
+	Name      string `json:"name"`
+}
+
+type exportedDevice struct {
+	Calibration   calibration   `json:"calibration"`
+	WarmUp        time.Duration `json:"warm_up"`
+	LenientUpdate bool          `json:"lenient_update"`
+	ClampHumidity bool          `json:"clamp_humidity"`
+	ReadDelay     time.Duration `json:"read_delay"`
+}
+
+// ExportConfig serialises t's ErrorMode, the registered quirks and accuracy
+// specs, and the calibration and options of every device registered with
+// Track, as JSON. Durations are in nanoseconds.
+func (t *Tempered) ExportConfig() ([]byte, error) {
+	cfg := exportedConfig{
+		ErrorMode: t.ErrorMode,
+		Quirks:    []exportedQuirk{},
+		Accuracy:  map[string]AccuracySpec{},
+		Devices:   map[string]exportedDevice{},
+	}
+
+	quirksLock.RLock()
+	for key, q := range quirks {
+		cfg.Quirks = append(cfg.Quirks, exportedQuirk{key.vendorId, key.productId, q.Name})
+	}
+	quirksLock.RUnlock()
+	sort.Slice(cfg.Quirks, func(i, j int) bool {
+		a, b := cfg.Quirks[i], cfg.Quirks[j]
+		if a.VendorId != b.VendorId {
+			return a.VendorId < b.VendorId
+		}
+		return a.ProductId < b.ProductId
+	})
+
+	accuracyLock.RLock()
+	for typeName, spec := range accuracySpecs {
+		cfg.Accuracy[typeName] = spec
+	}
+	accuracyLock.RUnlock()
+
+	t.trackLock.Lock()
+	for _, td := range t.tracked {
+		cfg.Devices[td.ID()] = exportedDevice{
+			Calibration:   td.calibration(),
+			WarmUp:        td.WarmUp,
+			LenientUpdate: td.LenientUpdate,
+			ClampHumidity: td.ClampHumidity,
+			ReadDelay:     td.ReadDelay,
+		}
+	}
+	t.trackLock.Unlock()
+
+	return json.MarshalIndent(cfg, "", "  ")
+}
+
+// ImportConfig restores a configuration written by ExportConfig. Quirks are
+// code and cannot be restored, so it fails without changing anything if a
+// listed quirk is not registered under the same name. Otherwise it sets
+// ErrorMode and the accuracy specs, applies device settings to tracked
+// devices with a matching ID, and saves every device's calibration to the
+// CalibrationStore, if there is one, for devices opened later.
+func (t *Tempered) ImportConfig(data []byte) error {
+	var cfg exportedConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return err
+	}
+	for _, eq := range cfg.Quirks {
+		if q, ok := QuirkFor(eq.VendorId, eq.ProductId); !ok || q.Name != eq.Name {
+			return fmt.Errorf("tempered: quirk %q for %04x:%04x is not registered", eq.Name, eq.VendorId, eq.ProductId)
+		}
+	}
+
+	t.ErrorMode = cfg.ErrorMode
+	for typeName, spec := range cfg.Accuracy {
+		SetAccuracy(typeName, spec)
+	}
+
+	t.trackLock.Lock()
+	for _, td := range t.tracked {
+		dc, ok := cfg.Devices[td.ID()]
+		if !ok {
+			continue
+		}
+		td.SetCalibration(dc.Calibration.Temperature, dc.Calibration.Humidity)
+		td.WarmUp = dc.WarmUp
+		td.LenientUpdate = dc.LenientUpdate
+		td.ClampHumidity = dc.ClampHumidity
+		td.ReadDelay = dc.ReadDelay
+	}
+	t.trackLock.Unlock()
+
+	if t.CalibrationStore != nil {
+		for id, dc := range cfg.Devices {
+			if err := t.CalibrationStore.Save(id, dc.Calibration.Temperature, dc.Calibration.Humidity); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package temperedgo
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigRoundTrip(t *testing.T) {
+	fb := &FakeBackend{Devices: []*FakeDevice{{
+		Path:    "/dev/fake0",
+		Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_ALL}},
+	}}}
+	td := openFake(t, fb)
+	td.SetCalibration(-0.5, 2)
+	td.WarmUp = time.Second
+	td.LenientUpdate = true
+	td.ClampHumidity = true
+	td.ReadDelay = 10 * time.Millisecond
+	RegisterQuirk(0xfffe, 0x0010, TemperatureOffsetQuirk(1))
+	SetAccuracy("TEMPerConfigTest", AccuracySpec{Temperature: 0.5, Humidity: 3})
+
+	src := &Tempered{Backend: fb, ErrorMode: ERROR_MODE_FAIL_FAST}
+	src.Track(td)
+	data, err := src.ExportConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	SetAccuracy("TEMPerConfigTest", AccuracySpec{})
+	other := TemperedDevice{Path: td.Path}
+	dst := &Tempered{Backend: fb}
+	dst.Track(&other)
+	if err := dst.ImportConfig(data); err != nil {
+		t.Fatalf("ImportConfig: %v", err)
+	}
+
+	if dst.ErrorMode != ERROR_MODE_FAIL_FAST {
+		t.Errorf("ErrorMode = %v, want ERROR_MODE_FAIL_FAST", dst.ErrorMode)
+	}
+	if spec, _ := Accuracy("TEMPerConfigTest"); spec != (AccuracySpec{Temperature: 0.5, Humidity: 3}) {
+		t.Errorf("Accuracy = %+v after import, want the exported spec", spec)
+	}
+	if cal := other.calibration(); cal.Temperature != -0.5 || cal.Humidity != 2 {
+		t.Errorf("calibration = %+v after import, want -0.5, 2", cal)
+	}
+	if other.WarmUp != time.Second || !other.LenientUpdate || !other.ClampHumidity || other.ReadDelay != 10*time.Millisecond {
+		t.Errorf("device options not restored: warm up %v, lenient %v, clamp %v, read delay %v",
+			other.WarmUp, other.LenientUpdate, other.ClampHumidity, other.ReadDelay)
+	}
+}
+
+func TestImportConfigNeedsQuirks(t *testing.T) {
+	data := `{"quirks": [{"vendor_id": 65534, "product_id": 17, "name": "not registered"}]}`
+	tm := &Tempered{ErrorMode: ERROR_MODE_COLLECT}
+	err := tm.ImportConfig([]byte(data))
+	if err == nil || !strings.Contains(err.Error(), "not registered") {
+		t.Fatalf("ImportConfig = %v, want an unregistered quirk error", err)
+	}
+	if tm.ErrorMode != ERROR_MODE_COLLECT {
+		t.Error("a failed ImportConfig changed ErrorMode")
+	}
+}
@@ -0,0 +1,39 @@
+package temperedgo
+
+import (
+	"fmt"
+)
+
+// ConfirmedTemperature updates the device and reads the temperature samples
+// times, returning their mean only if every sample is within tolerance
+// degrees Celsius of every other; otherwise it fails with
+// ERR_UNSTABLE_READING. At least one sample is always taken.
+func (ts *TemperedSensor) ConfirmedTemperature(samples int, tolerance float64) (Celsius, error) {
+	if samples < 1 {
+		samples = 1
+	}
+
+	var lo, hi, sum float64
+	for n := 0; n < samples; n++ {
+		if err := ts.device.Update(); err != nil {
+			return 0, err
+		}
+		val, err := ts.Temperature()
+		if err != nil {
+			return 0, err
+		}
+		v := val.Float64()
+		if n == 0 || v < lo {
+			lo = v
+		}
+		if n == 0 || v > hi {
+			hi = v
+		}
+		sum += v
+	}
+
+	if hi-lo > tolerance {
+		return 0, ts.device.sensorError(ts.sensorNum, fmt.Errorf("%d samples spread %g°C, over %g°C: %w", samples, hi-lo, tolerance, ERR_UNSTABLE_READING))
+	}
+	return Celsius(sum / float64(samples)), nil
+}
@@ -0,0 +1,164 @@
+package temperedgo
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	orphanedOpens atomic.Int64
+	leakedOpens   atomic.Int64
+)
+
+// DeviceListContext is DeviceList, returning ctx.Err() if ctx is done before
+// enumeration finishes. The enumeration itself cannot be interrupted and runs
+// to completion in the background; its native device list is freed when it
+// does, so abandoning it does not leak.
+func (t *Tempered) DeviceListContext(ctx context.Context) ([]TemperedDevice, error) {
+	type result struct {
+		tds []TemperedDevice
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		tds, err := t.DeviceList()
+		done <- result{tds, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-done:
+		return res.tds, res.err
+	}
+}
+
+// CloseTimeout is Close, giving up after d if the native close hangs, as it
+// can after a device is unplugged. The device is marked closed either way so
+// the caller can move on, but after a timeout the native handle may never be
+// released and leaks for the life of the process, and Exit blocks until the
+// abandoned close does finish.
+func (t *TemperedDevice) CloseTimeout(d time.Duration) error {
+	t.mutex().Lock()
+	dev := t.detach()
+	t.mutex().Unlock()
+	if dev == nil {
+		return nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		libLock.RLock()
+		defer libLock.RUnlock()
+
+		waitNative(dev)
+		dev.Close()
+		close(done)
+	}()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return nil
+	case <-timer.C:
+		return t.deviceError(ERR_TIMEOUT)
+	}
+}
+
+// OpenContext is Open, returning ctx.Err() if ctx is done before the native
+// open finishes. An open abandoned this way carries on in the background;
+// if it then succeeds its handle is closed straight away, and counted in
+// OrphanedOpens. A handle that can no longer be closed because the library
+// was exited in the meantime is counted in LeakedOpens instead.
+func (t *TemperedDevice) OpenContext(ctx context.Context) error {
+	t.mutex().Lock()
+	if t.dev != nil {
+		t.mutex().Unlock()
+		return nil
+	}
+	td := *t
+	t.mutex().Unlock()
+
+	type result struct {
+		dev DeviceHandle
+		err error
+	}
+	// The background open either hands its result over on done, which never
+	// blocks, or, once the caller has given up, closes it itself. lock makes
+	// that choice and the caller giving up mutually exclusive.
+	var lock sync.Mutex
+	abandoned := false
+	done := make(chan result, 1)
+	go func() {
+		var res result
+		if res.err = acquireLib(td.backendOrDefault()); res.err != nil {
+			done <- res
+			return
+		}
+		// libLock is held until the handle is either closed or handed over,
+		// so that Exit can't come between.
+		defer libLock.RUnlock()
+		res.dev, res.err = td.backendOrDefault().Open(&td)
+
+		lock.Lock()
+		defer lock.Unlock()
+		if abandoned {
+			if res.dev != nil {
+				res.dev.Close()
+				orphanedOpens.Add(1)
+			}
+			return
+		}
+		done <- res
+	}()
+
+	var res result
+	select {
+	case <-ctx.Done():
+		lock.Lock()
+		abandoned = true
+		lock.Unlock()
+		select {
+		case res = <-done:
+			// The open finished before it could be abandoned.
+		default:
+			return t.deviceError(ctx.Err())
+		}
+	case res = <-done:
+	}
+
+	if res.err != nil {
+		return t.deviceError(res.err)
+	}
+	if err := acquireLib(td.backendOrDefault()); err != nil {
+		// Exited since the open: the handle can't be closed or used.
+		leakedOpens.Add(1)
+		return t.deviceError(err)
+	}
+	defer libLock.RUnlock()
+	t.mutex().Lock()
+	defer t.mutex().Unlock()
+	if t.dev != nil {
+		// Opened concurrently by someone else; keep theirs.
+		res.dev.Close()
+		return nil
+	}
+	t.opened(res.dev)
+	return nil
+}
+
+// OrphanedOpens is the number of native opens that succeeded after
+// OpenContext had given up on them. A steadily rising count suggests the
+// open timeouts are too short.
+func OrphanedOpens() int64 {
+	return orphanedOpens.Load()
+}
+
+// LeakedOpens is the number of handles from OpenContext that were left open
+// because Exit was called before they could be closed.
+func LeakedOpens() int64 {
+	return leakedOpens.Load()
+}
@@ -0,0 +1,159 @@
+package temperedgo
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOpenContextNeverLosesAnOpen(t *testing.T) {
+	fb := &FakeBackend{Devices: []*FakeDevice{{
+		Path:    "/dev/fake0",
+		Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_TEMPERATURE}},
+	}}}
+	tm := &Tempered{Backend: fb}
+	if err := tm.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer tm.Exit()
+	tds, err := tm.DeviceList()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orphaned := OrphanedOpens()
+	// The deadline is never meant to be reached; it turns a lost open,
+	// which would leave OpenContext waiting on ctx, into a failure.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 2000; i++ {
+				td := tds[0]
+				td.lock = new(sync.Mutex)
+				if err := td.OpenContext(ctx); err != nil {
+					t.Errorf("OpenContext: %v", err)
+					return
+				}
+				td.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := OrphanedOpens() - orphaned; n != 0 {
+		t.Errorf("%d opens were orphaned although ctx was never meant to be done", n)
+	}
+	if n := fb.OpenHandles(); n != 0 {
+		t.Errorf("OpenHandles() = %d after closing everything, want 0", n)
+	}
+}
+
+func TestOpenContextDone(t *testing.T) {
+	fb := &FakeBackend{Devices: []*FakeDevice{{Path: "/dev/fake0"}}}
+	tm := &Tempered{Backend: fb}
+	if err := tm.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer tm.Exit()
+	tds, err := tm.DeviceList()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	td := &tds[0]
+	if err := td.OpenContext(ctx); err != nil {
+		// The open may or may not have beaten the cancellation; either way
+		// it must not be left open.
+		if td.dev != nil {
+			t.Error("OpenContext failed but left the device open")
+		}
+	}
+	td.Close()
+	for i := 0; fb.OpenHandles() != 0 && i < 1000; i++ {
+		runtime.Gosched()
+	}
+	if n := fb.OpenHandles(); n != 0 {
+		t.Errorf("OpenHandles() = %d after an abandoned open, want 0", n)
+	}
+}
+
+// slowOpenBackend holds every Open until release is closed, and records how
+// many handles were still open when it was exited. Its handles are slow to
+// close, so that a close racing Exit loses.
+type slowOpenBackend struct {
+	*FakeBackend
+	release      chan struct{}
+	openedAtExit *int
+}
+
+type slowCloseHandle struct {
+	DeviceHandle
+}
+
+func (h slowCloseHandle) Close() {
+	time.Sleep(10 * time.Millisecond)
+	h.DeviceHandle.Close()
+}
+
+func (b slowOpenBackend) Open(t *TemperedDevice) (DeviceHandle, error) {
+	<-b.release
+	dev, err := b.FakeBackend.Open(t)
+	if err != nil {
+		return nil, err
+	}
+	return slowCloseHandle{dev}, nil
+}
+
+func (b slowOpenBackend) Exit() error {
+	*b.openedAtExit = b.OpenHandles()
+	return b.FakeBackend.Exit()
+}
+
+func TestOpenContextOrphanClosedBeforeExit(t *testing.T) {
+	var openedAtExit int
+	b := slowOpenBackend{
+		FakeBackend:  &FakeBackend{Devices: []*FakeDevice{{Path: "/dev/fake0"}}},
+		release:      make(chan struct{}),
+		openedAtExit: &openedAtExit,
+	}
+	tm := &Tempered{Backend: b}
+	if err := tm.Init(); err != nil {
+		t.Fatal(err)
+	}
+	tds, err := tm.DeviceList()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orphaned := OrphanedOpens()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := tds[0].OpenContext(ctx); err == nil {
+		t.Fatal("OpenContext succeeded although the open was held up")
+	}
+
+	// Exit must wait for the abandoned open, which then closes its handle
+	// while the library is still up.
+	exited := make(chan error, 1)
+	go func() { exited <- tm.Exit() }()
+	time.Sleep(10 * time.Millisecond)
+	close(b.release)
+	if err := <-exited; err != nil {
+		t.Fatal(err)
+	}
+
+	if openedAtExit != 0 {
+		t.Errorf("%d handles were still open when the library was exited", openedAtExit)
+	}
+	if n := OrphanedOpens() - orphaned; n != 1 {
+		t.Errorf("OrphanedOpens rose by %d, want 1", n)
+	}
+}
@@ -0,0 +1,209 @@
+package temperedgo
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var csvHeader = []string{"time", "device_id", "sensor_num", "label", "temperature", "humidity"}
+
+// CSVRotation controls when a CSVLogger starts a new file. A zero limit is
+// not applied, so the zero value never rotates. FlushInterval is how often
+// buffered rows are written out; zero flushes after every Write.
+type CSVRotation struct {
+	MaxBytes      int64
+	MaxAge        time.Duration
+	FlushInterval time.Duration
+}
+
+// CSVLogger appends readings as CSV rows to files in a directory, starting
+// a new file, headed by the column names, whenever the current one exceeds
+// its rotation limits.
+type CSVLogger struct {
+	dir    string
+	prefix string
+	rot    CSVRotation
+
+	lock    sync.Mutex
+	file    *os.File
+	w       *csv.Writer
+	name    string
+	size    int64
+	created time.Time
+	stop    chan struct{}
+}
+
+// countingWriter counts the bytes the CSV writer flushes to the file. Rows
+// still in the CSV writer's buffer aren't counted, so a file can overshoot
+// MaxBytes by up to that buffer's size.
+type countingWriter struct {
+	l *CSVLogger
+}
+
+func (cw countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.l.file.Write(p)
+	cw.l.size += int64(n)
+	return n, err
+}
+
+// NewCSVLogger creates the first file, named after prefix and the current
+// time, in dir.
+func NewCSVLogger(dir, prefix string, rot CSVRotation) (*CSVLogger, error) {
+	l := &CSVLogger{dir: dir, prefix: prefix, rot: rot}
+	if err := l.rotate(); err != nil {
+		return nil, err
+	}
+	if rot.FlushInterval > 0 {
+		l.stop = make(chan struct{})
+		go l.flushLoop()
+	}
+	return l, nil
+}
+
+func (l *CSVLogger) flushLoop() {
+	ticker := time.NewTicker(l.rot.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+			l.Flush()
+		}
+	}
+}
+
+// rotate closes the current file, if any, and starts a new one. The caller
+// must hold l.lock.
+func (l *CSVLogger) rotate() error {
+	if l.file != nil {
+		if err := l.closeFile(); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	base := l.prefix + "-" + now.UTC().Format("20060102T150405Z")
+	var f *os.File
+	var name string
+	for n := 0; ; n++ {
+		name = filepath.Join(l.dir, base+".csv")
+		if n > 0 {
+			name = filepath.Join(l.dir, fmt.Sprintf("%s-%d.csv", base, n))
+		}
+		var err error
+		f, err = os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		break
+	}
+
+	l.file = f
+	l.name = name
+	l.size = 0
+	l.created = now
+	l.w = csv.NewWriter(countingWriter{l})
+	return l.w.Write(csvHeader)
+}
+
+func (l *CSVLogger) flush() error {
+	l.w.Flush()
+	return l.w.Error()
+}
+
+func (l *CSVLogger) closeFile() error {
+	err := l.flush()
+	if cerr := l.file.Close(); err == nil {
+		err = cerr
+	}
+	l.file = nil
+	return err
+}
+
+func (l *CSVLogger) needsRotation() bool {
+	if l.rot.MaxBytes > 0 && l.size >= l.rot.MaxBytes {
+		return true
+	}
+	return l.rot.MaxAge > 0 && time.Since(l.created) >= l.rot.MaxAge
+}
+
+func formatOptional(val *float64) string {
+	if val == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*val, 'f', -1, 64)
+}
+
+// Write appends a row per reading, rotating first if the current file is
+// over its limits.
+func (l *CSVLogger) Write(rs []Reading) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.file == nil {
+		return os.ErrClosed
+	}
+	if l.needsRotation() {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+	for _, r := range rs {
+		row := []string{
+			r.Time.Format(time.RFC3339Nano),
+			r.DeviceID,
+			strconv.Itoa(r.SensorNum),
+			r.Label,
+			formatOptional(r.Temperature),
+			formatOptional(r.Humidity),
+		}
+		if err := l.w.Write(row); err != nil {
+			return err
+		}
+	}
+	if l.rot.FlushInterval <= 0 {
+		return l.flush()
+	}
+	return nil
+}
+
+// FileName is the path of the file currently being written.
+func (l *CSVLogger) FileName() string {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.name
+}
+
+func (l *CSVLogger) Flush() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+	return l.flush()
+}
+
+// Close flushes and closes the current file.
+func (l *CSVLogger) Close() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+	if l.stop != nil {
+		close(l.stop)
+	}
+	return l.closeFile()
+}
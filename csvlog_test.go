@@ -0,0 +1,58 @@
+package temperedgo
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCSVLoggerRotatesBySize(t *testing.T) {
+	dir := t.TempDir()
+	l, err := NewCSVLogger(dir, "temps", CSVRotation{MaxBytes: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	temp := 21.5
+	at := time.Unix(1700000000, 0).UTC()
+	for i := 0; i < 6; i++ {
+		if err := l.Write([]Reading{{Time: at, DeviceID: "/dev/fake0", SensorNum: i, Temperature: &temp}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Write(nil); err == nil {
+		t.Error("Write after Close succeeded, want an error")
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "temps-*.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) < 2 {
+		t.Fatalf("wrote %d files, want the 100-byte limit to have rotated", len(files))
+	}
+	rows := 0
+	for _, fn := range files {
+		f, err := os.Open(fn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		records, err := csv.NewReader(f).ReadAll()
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", fn, err)
+		}
+		if len(records) == 0 || records[0][0] != "time" {
+			t.Errorf("%s does not start with the header", fn)
+			continue
+		}
+		rows += len(records) - 1
+	}
+	if rows != 6 {
+		t.Errorf("found %d rows across the files, want 6", rows)
+	}
+}
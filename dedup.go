@@ -0,0 +1,70 @@
+package temperedgo
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// hidrawUSBInterface finds, through sysfs, the USB interface behind a hidraw
+// device node, named as "<port path>:<config>.<interface>", e.g.
+// "1-1.2:1.0".
+func hidrawUSBInterface(path string) (string, bool) {
+	if !strings.HasPrefix(path, "/dev/hidraw") {
+		return "", false
+	}
+	sysPath, err := filepath.EvalSymlinks(filepath.Join("/sys/class/hidraw", filepath.Base(path), "device"))
+	if err != nil {
+		return "", false
+	}
+	// .../<usb device>/<usb device>:<config>.<interface>/<hid device>
+	iface := filepath.Base(filepath.Dir(sysPath))
+	if !strings.Contains(iface, ":") {
+		return "", false
+	}
+	return iface, true
+}
+
+// physicalDeviceKey returns a key shared by every HID interface of the same
+// USB device. For hidraw paths the USB device is found through sysfs, for
+// libusb-style "bus:address:interface" paths the interface is stripped, and
+// anything else falls back to the path itself.
+func physicalDeviceKey(td TemperedDevice) string {
+	usbPath := td.Path
+	if iface, ok := hidrawUSBInterface(td.Path); ok {
+		if n := strings.IndexByte(iface, ':'); n > 0 {
+			usbPath = iface[:n]
+		}
+	} else if parts := strings.Split(td.Path, ":"); len(parts) == 3 {
+		usbPath = parts[0] + ":" + parts[1]
+	}
+	return fmt.Sprintf("%04x:%04x:%s", td.VendorId, td.ProductId, usbPath)
+}
+
+// DedupDevices collapses devices enumerated once per HID interface into a
+// single entry per physical device, keeping the lowest interface number.
+// Enumeration order is otherwise preserved.
+func DedupDevices(devs []TemperedDevice) []TemperedDevice {
+	seen := make(map[string]int)
+	unique := []TemperedDevice{}
+	for _, td := range devs {
+		key := physicalDeviceKey(td)
+		if n, ok := seen[key]; ok {
+			if td.InterfaceNumber < unique[n].InterfaceNumber {
+				unique[n] = td
+			}
+			continue
+		}
+		seen[key] = len(unique)
+		unique = append(unique, td)
+	}
+	return unique
+}
+
+func (t *Tempered) DeviceListUnique() ([]TemperedDevice, error) {
+	tds, err := t.DeviceList()
+	if err != nil {
+		return nil, err
+	}
+	return DedupDevices(tds), nil
+}
@@ -0,0 +1,53 @@
+package temperedgo
+
+import (
+	"errors"
+	"time"
+)
+
+// TemperatureDelta updates the device once and returns sensor a's
+// temperature minus sensor b's, in degrees Celsius, after quirks and
+// calibration. Both sensors must measure temperature. The difference is a
+// plain float64 rather than a Celsius, since converting a difference to
+// another unit only scales it.
+func (t *TemperedDevice) TemperatureDelta(sensorA, sensorB int) (float64, error) {
+	t.waitWarmUp()
+
+	if err := acquireLib(t.backendOrDefault()); err != nil {
+		return 0, t.deviceError(err)
+	}
+	defer libLock.RUnlock()
+	t.lockDevice()
+	defer t.unlockDevice()
+
+	if t.dev == nil {
+		return 0, t.deviceError(ERR_NOT_OPEN)
+	}
+	for _, n := range []int{sensorA, sensorB} {
+		if n < 0 || n >= t.dev.SensorCount() {
+			return 0, t.sensorError(n, ERR_SENSOR_OUT_OF_RANGE)
+		}
+		if !t.dev.SensorType(n).IsType(TEMPERED_SENSOR_TYPE_TEMPERATURE) {
+			return 0, t.sensorError(n, ERR_UNSUPPORTED_MEASUREMENT)
+		}
+	}
+
+	if err := t.update(); err != nil && !errors.Is(err, ERR_PARTIAL_UPDATE) {
+		return 0, err
+	}
+
+	cal := t.calibration()
+	var temps [2]float64
+	for i, n := range []int{sensorA, sensorB} {
+		start := time.Now()
+		val, ok := t.dev.Temperature(n)
+		if !ok {
+			err := t.sensorError(n, &TemperedError{Op: OP_TEMPERATURE, Err: ERR_FAILED_RETRIEVE})
+			t.observe(OP_TEMPERATURE, n, start, 0, err)
+			return 0, err
+		}
+		temps[i] = t.correctTemperature(n, val, cal)
+		t.observe(OP_TEMPERATURE, n, start, temps[i], nil)
+	}
+	return temps[0] - temps[1], nil
+}
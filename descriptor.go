@@ -0,0 +1,49 @@
+package temperedgo
+
+import (
+	"fmt"
+)
+
+// DeviceDescriptor identifies a device independently of any open handle, so
+// it can be stored in configuration and reopened later.
+type DeviceDescriptor struct {
+	Path            string `json:"path"`
+	TypeName        string `json:"type_name"`
+	VendorId        uint   `json:"vendor_id"`
+	ProductId       uint   `json:"product_id"`
+	InterfaceNumber int    `json:"interface_number"`
+}
+
+func (t *TemperedDevice) Descriptor() DeviceDescriptor {
+	return DeviceDescriptor{
+		Path:            t.Path,
+		TypeName:        t.TypeName,
+		VendorId:        t.VendorId,
+		ProductId:       t.ProductId,
+		InterfaceNumber: t.InterfaceNumber,
+	}
+}
+
+// OpenDescriptor enumerates devices and opens the one matching dd. Every
+// field must match, so if the device has since been replaced by a different
+// model at the same path it fails with ERR_NO_DEVICE_FOUND rather than
+// opening the wrong one.
+func (t *Tempered) OpenDescriptor(dd DeviceDescriptor) (*TemperedDevice, error) {
+	tds, err := t.DeviceList()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, td := range tds {
+		if td.Descriptor() != dd {
+			continue
+		}
+		dev := td
+		if err := dev.Open(); err != nil {
+			return nil, err
+		}
+		return &dev, nil
+	}
+
+	return nil, fmt.Errorf("%s: %w", dd.Path, ERR_NO_DEVICE_FOUND)
+}
@@ -0,0 +1,34 @@
+package temperedgo
+
+import (
+	"time"
+)
+
+// DeviceListCached returns the result of the last enumeration if it is
+// younger than ttl, and enumerates afresh otherwise. Cached entries may refer
+// to devices that have since been unplugged; call InvalidateDeviceCache from
+// a hotplug handler to force the next call to re-enumerate.
+func (t *Tempered) DeviceListCached(ttl time.Duration) ([]TemperedDevice, error) {
+	t.cacheLock.Lock()
+	defer t.cacheLock.Unlock()
+
+	if t.cacheDevices == nil || time.Since(t.cacheTime) >= ttl {
+		tds, err := t.DeviceList()
+		if err != nil {
+			return nil, err
+		}
+		t.cacheDevices = tds
+		t.cacheTime = time.Now()
+	}
+
+	tds := make([]TemperedDevice, len(t.cacheDevices))
+	copy(tds, t.cacheDevices)
+	return tds, nil
+}
+
+func (t *Tempered) InvalidateDeviceCache() {
+	t.cacheLock.Lock()
+	defer t.cacheLock.Unlock()
+
+	t.cacheDevices = nil
+}
@@ -0,0 +1,57 @@
+package temperedgo
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// Diagnostics describes the environment and every enumerated device, with a
+// sample reading from each, in a compact form for pasting into bug reports.
+// libtempered has no version query, so only the Go side's build is
+// reported. Per-device failures are included in the text; the error is
+// only set if devices could not be enumerated at all, in which case the
+// text still describes the environment.
+func (t *Tempered) Diagnostics() (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "go: %s %s/%s, cgo: %t\n", runtime.Version(), runtime.GOOS, runtime.GOARCH, CgoEnabled())
+	fmt.Fprintf(&b, "backend: %T\n", t.backendOrDefault())
+
+	tds, err := t.DeviceList()
+	if err != nil {
+		fmt.Fprintf(&b, "enumerate: %v\n", err)
+		return b.String(), err
+	}
+	fmt.Fprintf(&b, "devices: %d\n", len(tds))
+
+	for n, td := range tds {
+		fmt.Fprintf(&b, "[%d] %s %s %04x:%04x if%d\n", n, td.ID(), td.TypeName, td.VendorId, td.ProductId, td.InterfaceNumber)
+
+		dev := td
+		if err := dev.Open(); err != nil {
+			fmt.Fprintf(&b, "    open: %v\n", err)
+			continue
+		}
+		if count, err := dev.SensorCount(); err != nil {
+			fmt.Fprintf(&b, "    sensors: %v\n", err)
+		} else {
+			fmt.Fprintf(&b, "    sensors: %d\n", count)
+		}
+		rs, err := dev.ReadAll()
+		if err != nil {
+			fmt.Fprintf(&b, "    read: %v\n", err)
+		}
+		for _, r := range rs {
+			fmt.Fprintf(&b, "    sensor %d %s:", r.SensorNum, strings.Join(r.Type.Names(), "+"))
+			if r.Temperature != nil {
+				fmt.Fprintf(&b, " %g°C", *r.Temperature)
+			}
+			if r.Humidity != nil {
+				fmt.Fprintf(&b, " %g%%RH", *r.Humidity)
+			}
+			b.WriteString("\n")
+		}
+		dev.Close()
+	}
+	return b.String(), nil
+}
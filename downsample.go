@@ -0,0 +1,121 @@
+package temperedgo
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Bucket summarises one sensor's readings over [Start, Start+size). Mean,
+// Min and Max carry the sensor's identifying fields and are stamped with
+// Start; each measurement is aggregated independently over the readings
+// that have it.
+type Bucket struct {
+	Start time.Time
+	Count int
+	Mean  Reading
+	Min   Reading
+	Max   Reading
+}
+
+type seriesKey struct {
+	deviceID  string
+	sensorNum int
+}
+
+// Downsampler aggregates readings into fixed time buckets per sensor,
+// calling emit with each bucket once it is complete: when a reading for the
+// same sensor arrives in a later bucket, or on Flush. Readings that arrive
+// for a bucket already emitted start a new bucket for that period. It is
+// safe for concurrent use; emit is called with no lock held.
+type Downsampler struct {
+	size time.Duration
+	emit func(Bucket)
+
+	lock    sync.Mutex
+	pending map[seriesKey][]Reading
+}
+
+// NewDownsampler returns an error if size isn't positive or emit is nil.
+func NewDownsampler(size time.Duration, emit func(Bucket)) (*Downsampler, error) {
+	if size <= 0 {
+		return nil, errors.New("tempered: downsampling bucket size must be positive")
+	}
+	if emit == nil {
+		return nil, errors.New("tempered: downsampling needs an emit function")
+	}
+	return &Downsampler{size: size, emit: emit, pending: map[seriesKey][]Reading{}}, nil
+}
+
+func (d *Downsampler) Add(r Reading) {
+	key := seriesKey{r.DeviceID, r.SensorNum}
+	start := r.Time.Truncate(d.size)
+
+	d.lock.Lock()
+	var done []Reading
+	if rs := d.pending[key]; len(rs) > 0 && !rs[0].Time.Truncate(d.size).Equal(start) {
+		done = rs
+		d.pending[key] = nil
+	}
+	d.pending[key] = append(d.pending[key], r)
+	d.lock.Unlock()
+
+	if done != nil {
+		d.emit(d.bucket(done))
+	}
+}
+
+// Flush emits every incomplete bucket, oldest first.
+func (d *Downsampler) Flush() {
+	d.lock.Lock()
+	var buckets []Bucket
+	for key, rs := range d.pending {
+		if len(rs) > 0 {
+			buckets = append(buckets, d.bucket(rs))
+		}
+		delete(d.pending, key)
+	}
+	d.lock.Unlock()
+
+	sort.SliceStable(buckets, func(i, j int) bool {
+		return buckets[i].Start.Before(buckets[j].Start)
+	})
+	for _, b := range buckets {
+		d.emit(b)
+	}
+}
+
+func (d *Downsampler) bucket(rs []Reading) Bucket {
+	b := Bucket{Start: rs[0].Time.Truncate(d.size), Count: len(rs)}
+
+	base := rs[0]
+	base.Time = b.Start
+	base.Temperature, base.Humidity = nil, nil
+	base.TemperatureUncertainty, base.HumidityUncertainty = nil, nil
+	b.Min, b.Max = base, base
+
+	b.Mean = AverageReadings(rs)
+	b.Mean.Time, b.Mean.DeviceID, b.Mean.SensorNum = b.Start, base.DeviceID, base.SensorNum
+	b.Mean.Role, b.Mean.Label, b.Mean.Tags = base.Role, base.Label, base.Tags
+
+	for _, r := range rs {
+		b.Min.Temperature = extreme(b.Min.Temperature, r.Temperature, false)
+		b.Max.Temperature = extreme(b.Max.Temperature, r.Temperature, true)
+		b.Min.Humidity = extreme(b.Min.Humidity, r.Humidity, false)
+		b.Max.Humidity = extreme(b.Max.Humidity, r.Humidity, true)
+	}
+	b.Min.Type, b.Max.Type = b.Mean.Type, b.Mean.Type
+	return b
+}
+
+func extreme(cur, val *float64, max bool) *float64 {
+	if val == nil {
+		return cur
+	}
+	if cur == nil || (max && *val > *cur) || (!max && *val < *cur) {
+		v := *val
+		return &v
+	}
+	return cur
+}
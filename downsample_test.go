@@ -0,0 +1,56 @@
+package temperedgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewDownsamplerValidates(t *testing.T) {
+	if _, err := NewDownsampler(0, func(Bucket) {}); err == nil {
+		t.Error("NewDownsampler(0, ...) succeeded, want an error")
+	}
+	if _, err := NewDownsampler(-time.Minute, func(Bucket) {}); err == nil {
+		t.Error("NewDownsampler(-time.Minute, ...) succeeded, want an error")
+	}
+	if _, err := NewDownsampler(time.Minute, nil); err == nil {
+		t.Error("NewDownsampler(time.Minute, nil) succeeded, want an error")
+	}
+}
+
+func TestDownsampler(t *testing.T) {
+	var got []Bucket
+	d, err := NewDownsampler(time.Minute, func(b Bucket) { got = append(got, b) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	add := func(offset time.Duration, temp float64) {
+		d.Add(Reading{DeviceID: "dev", Time: start.Add(offset), Temperature: &temp})
+	}
+	add(0, 20)
+	add(20*time.Second, 22)
+	add(40*time.Second, 24)
+	if len(got) != 0 {
+		t.Fatalf("emitted %d buckets before the minute was over", len(got))
+	}
+	add(70*time.Second, 30)
+	if len(got) != 1 {
+		t.Fatalf("emitted %d buckets after the minute, want 1", len(got))
+	}
+	d.Flush()
+	if len(got) != 2 {
+		t.Fatalf("emitted %d buckets after Flush, want 2", len(got))
+	}
+
+	b := got[0]
+	if !b.Start.Equal(start) || b.Count != 3 {
+		t.Errorf("first bucket starts %v with %d readings, want %v with 3", b.Start, b.Count, start)
+	}
+	if *b.Mean.Temperature != 22 || *b.Min.Temperature != 20 || *b.Max.Temperature != 24 {
+		t.Errorf("first bucket mean/min/max = %v/%v/%v, want 22/20/24", *b.Mean.Temperature, *b.Min.Temperature, *b.Max.Temperature)
+	}
+	if b := got[1]; !b.Start.Equal(start.Add(time.Minute)) || b.Count != 1 || *b.Mean.Temperature != 30 {
+		t.Errorf("second bucket = %+v, want one reading of 30 from %v", b, start.Add(time.Minute))
+	}
+}
@@ -0,0 +1,60 @@
+package temperedgo
+
+import (
+	"math"
+	"sync"
+)
+
+// Drift is how far a reading has moved from the baseline. A measurement
+// missing from either the baseline or the reading has zero drift.
+type Drift struct {
+	Temperature float64
+	Humidity    float64
+	Exceeded    bool
+}
+
+// DriftDetector compares readings against a recorded baseline, flagging
+// drift beyond the thresholds. A zero threshold disables checking that
+// measurement. It is safe for concurrent use.
+type DriftDetector struct {
+	TemperatureThreshold float64
+	HumidityThreshold    float64
+
+	lock     sync.Mutex
+	baseline *ValueReading
+}
+
+func (d *DriftDetector) SetBaseline(r Reading) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	vr := r.Values()
+	d.baseline = &vr
+}
+
+// Check reports the drift of r from the baseline. The bool is false if no
+// baseline has been set.
+func (d *DriftDetector) Check(r Reading) (Drift, bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.baseline == nil {
+		return Drift{}, false
+	}
+
+	var drift Drift
+	vr := r.Values()
+	if d.baseline.HasTemperature() && vr.HasTemperature() {
+		drift.Temperature = vr.Temperature - d.baseline.Temperature
+		if d.TemperatureThreshold > 0 && math.Abs(drift.Temperature) > d.TemperatureThreshold {
+			drift.Exceeded = true
+		}
+	}
+	if d.baseline.HasHumidity() && vr.HasHumidity() {
+		drift.Humidity = vr.Humidity - d.baseline.Humidity
+		if d.HumidityThreshold > 0 && math.Abs(drift.Humidity) > d.HumidityThreshold {
+			drift.Exceeded = true
+		}
+	}
+	return drift, true
+}
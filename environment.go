@@ -0,0 +1,63 @@
+package temperedgo
+
+import (
+	"fmt"
+	"time"
+)
+
+// Environment is a device-wide temperature and humidity assembled from
+// whichever sensors are best placed to provide each.
+type Environment struct {
+	Time              time.Time
+	Temperature       float64
+	Humidity          float64
+	TemperatureSensor int
+	HumiditySensor    int
+}
+
+// CombinedEnvironment updates the device and merges its sensors into one
+// Environment. The temperature comes from the lowest-numbered sensor that
+// measures only temperature, falling back to the lowest-numbered sensor that
+// measures temperature at all; dedicated temperature sensors are preferred
+// because combined sensors tend to self-heat. The humidity comes from the
+// lowest-numbered humidity sensor. It is an ERR_UNSUPPORTED_MEASUREMENT error
+// if the device lacks either measurement.
+func (t *TemperedDevice) CombinedEnvironment() (Environment, error) {
+	rs, err := t.ReadAll()
+	if err != nil {
+		return Environment{}, err
+	}
+
+	var temp, tempOnly, hum *Reading
+	for n := range rs {
+		r := &rs[n]
+		if r.Temperature != nil {
+			if temp == nil {
+				temp = r
+			}
+			if tempOnly == nil && r.Humidity == nil {
+				tempOnly = r
+			}
+		}
+		if r.Humidity != nil && hum == nil {
+			hum = r
+		}
+	}
+	if tempOnly != nil {
+		temp = tempOnly
+	}
+	if temp == nil {
+		return Environment{}, t.deviceError(fmt.Errorf("no temperature sensor: %w", ERR_UNSUPPORTED_MEASUREMENT))
+	}
+	if hum == nil {
+		return Environment{}, t.deviceError(fmt.Errorf("no humidity sensor: %w", ERR_UNSUPPORTED_MEASUREMENT))
+	}
+
+	return Environment{
+		Time:              temp.Time,
+		Temperature:       *temp.Temperature,
+		Humidity:          *hum.Humidity,
+		TemperatureSensor: temp.SensorNum,
+		HumiditySensor:    hum.SensorNum,
+	}, nil
+}
@@ -0,0 +1,32 @@
+package temperedgo
+
+// Native operations named in a TemperedError, besides those reported to an
+// Observer.
+const (
+	OP_INIT         = "init"
+	OP_EXIT         = "exit"
+	OP_ENUMERATE    = "enumerate"
+	OP_OPEN         = "open"
+	OP_SEND_COMMAND = "send_command"
+)
+
+// TemperedError is a failed native operation. Op is one of the OP_*
+// constants, Message is libtempered's description of the failure when it
+// gave one, and Err is the ERR_* sentinel for the kind of failure, which
+// errors.Is matches.
+type TemperedError struct {
+	Op      string
+	Message string
+	Err     error
+}
+
+func (e *TemperedError) Error() string {
+	if e.Message == "" {
+		return e.Err.Error()
+	}
+	return e.Err.Error() + ": " + e.Message
+}
+
+func (e *TemperedError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,154 @@
+package temperedgo
+
+import (
+	"errors"
+	"testing"
+)
+
+// runLifecycle inits a Tempered on fb and takes its first device through
+// Open, Update, Temperature, Humidity and ReadAll, calling mid, if set,
+// after the update. It returns the first error, having closed the device
+// and exited either way.
+func runLifecycle(fb *FakeBackend, mid func()) (err error) {
+	tm := &Tempered{Backend: fb}
+	if err := tm.Init(); err != nil {
+		return err
+	}
+	defer func() {
+		if exitErr := tm.Exit(); err == nil {
+			err = exitErr
+		}
+	}()
+
+	tds, err := tm.DeviceList()
+	if err != nil {
+		return err
+	}
+	td := &tds[0]
+	if err := td.Open(); err != nil {
+		return err
+	}
+	defer td.Close()
+
+	if err := td.Update(); err != nil {
+		return err
+	}
+	if mid != nil {
+		mid()
+	}
+	if _, err := td.Temperature(0); err != nil {
+		return err
+	}
+	if _, err := td.Humidity(0); err != nil {
+		return err
+	}
+	_, err = td.ReadAll()
+	return err
+}
+
+func TestInjectedFailures(t *testing.T) {
+	injected := errors.New("injected")
+	for _, tc := range []struct {
+		name   string
+		inject func(fb *FakeBackend)
+		mid    func(fb *FakeBackend)
+		want   error
+		op     string // "" if the error is not a TemperedError
+	}{
+		{
+			name:   "init fails",
+			inject: func(fb *FakeBackend) { fb.InitErr = injected },
+			want:   ERR_INIT_FAILED,
+			op:     OP_INIT,
+		},
+		{
+			name:   "enumerate fails",
+			inject: func(fb *FakeBackend) { fb.EnumerateErr = injected },
+			want:   ERR_ENUMERATE_FAILED,
+			op:     OP_ENUMERATE,
+		},
+		{
+			name:   "open fails",
+			inject: func(fb *FakeBackend) { fb.Devices[0].OpenErr = injected },
+			want:   ERR_OPEN_FAILED,
+			op:     OP_OPEN,
+		},
+		{
+			name:   "read sensors fails",
+			inject: func(fb *FakeBackend) { fb.Devices[0].FailUpdate = true },
+			want:   ERR_FAILED_UPDATE,
+			op:     OP_UPDATE,
+		},
+		{
+			name:   "temperature fails",
+			inject: func(fb *FakeBackend) { fb.Devices[0].FailRead = true },
+			want:   ERR_FAILED_RETRIEVE,
+			op:     OP_TEMPERATURE,
+		},
+		{
+			name: "humidity fails",
+			// A temperature-only sensor, asked for humidity.
+			inject: func(fb *FakeBackend) { fb.Devices[0].Sensors[0].Type = TEMPERED_SENSOR_TYPE_TEMPERATURE },
+			want:   ERR_FAILED_RETRIEVE,
+			op:     OP_HUMIDITY,
+		},
+		{
+			name: "update in read all fails",
+			// The values cached by the first update still read; only
+			// ReadAll's own update fails.
+			mid:  func(fb *FakeBackend) { fb.Devices[0].FailUpdate = true },
+			want: ERR_FAILED_UPDATE,
+			op:   OP_UPDATE,
+		},
+		{
+			name: "disconnect mid-read",
+			// A disconnected device reports no sensors, as libtempered's
+			// does.
+			mid:  func(fb *FakeBackend) { fb.Devices[0].Disconnected = true },
+			want: ERR_SENSOR_OUT_OF_RANGE,
+		},
+		{
+			name:   "exit fails",
+			inject: func(fb *FakeBackend) { fb.ExitErr = injected },
+			want:   ERR_EXIT_FAILED,
+			op:     OP_EXIT,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fb := &FakeBackend{Devices: []*FakeDevice{{
+				Path:    "/dev/fake0",
+				Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_ALL, Temperature: 20, Humidity: 50}},
+			}}}
+			if tc.inject != nil {
+				tc.inject(fb)
+			}
+			var mid func()
+			if tc.mid != nil {
+				mid = func() {
+					fb.Lock()
+					defer fb.Unlock()
+					tc.mid(fb)
+				}
+			}
+
+			err := runLifecycle(fb, mid)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("error = %v, want %v", err, tc.want)
+			}
+			var te *TemperedError
+			if errors.As(err, &te) != (tc.op != "") {
+				t.Errorf("error %v: TemperedError %v, want %v", err, te != nil, tc.op != "")
+			} else if te != nil && te.Op != tc.op {
+				t.Errorf("TemperedError.Op = %q, want %q", te.Op, tc.op)
+			}
+			if n := fb.OpenHandles(); n != 0 {
+				t.Errorf("%d handles leaked", n)
+			}
+
+			// A failed Exit leaves the backend counted as inited.
+			libLock.Lock()
+			delete(libRefs, Backend(fb))
+			libLock.Unlock()
+		})
+	}
+}
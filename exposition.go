@@ -0,0 +1,117 @@
+package temperedgo
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func expositionLabels(device string, r Reading) string {
+	labels := fmt.Sprintf(`device="%s",sensor="%d"`, labelValueEscaper.Replace(device), r.SensorNum)
+	if r.Label != "" {
+		labels += fmt.Sprintf(`,label="%s"`, labelValueEscaper.Replace(r.Label))
+	}
+	used := map[string]bool{"device": true, "sensor": true, "label": true}
+	for _, k := range sortedTags(r.Tags) {
+		name := labelName(k)
+		if name == "device" || name == "sensor" || name == "label" {
+			continue
+		}
+		// Keys that sanitise to the same name are told apart by a suffix,
+		// taken in key order so that each keeps its name between scrapes.
+		for n, base := 2, name; used[name]; n++ {
+			name = fmt.Sprintf("%s_%d", base, n)
+		}
+		used[name] = true
+		labels += fmt.Sprintf(`,%s="%s"`, name, labelValueEscaper.Replace(r.Tags[k]))
+	}
+	return labels
+}
+
+// labelName makes a tag key a valid Prometheus label name, replacing
+// invalid characters with '_', prefixing '_' to one that starts with a
+// digit and "tag" to one that starts with the "__" Prometheus reserves.
+// Tags that would clash with the built-in labels are dropped by the caller,
+// and other clashes are given a numeric suffix.
+func labelName(k string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		}
+		return '_'
+	}, k)
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	if strings.HasPrefix(name, "__") {
+		name = "tag" + name
+	}
+	return name
+}
+
+func formatSampleValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+type metricFamily struct {
+	name, help, unit string
+	samples          strings.Builder
+}
+
+func readingFamilies(readings map[string][]Reading) []*metricFamily {
+	devices := make([]string, 0, len(readings))
+	for device := range readings {
+		devices = append(devices, device)
+	}
+	sort.Strings(devices)
+
+	temps := &metricFamily{name: "tempered_temperature_celsius", help: "Sensor temperature in degrees Celsius.", unit: "celsius"}
+	hums := &metricFamily{name: "tempered_humidity_percent", help: "Sensor relative humidity in percent.", unit: "percent"}
+	for _, device := range devices {
+		for _, r := range readings[device] {
+			if r.Temperature != nil {
+				fmt.Fprintf(&temps.samples, "%s{%s} %s\n", temps.name, expositionLabels(device, r), formatSampleValue(*r.Temperature))
+			}
+			if r.Humidity != nil {
+				fmt.Fprintf(&hums.samples, "%s{%s} %s\n", hums.name, expositionLabels(device, r), formatSampleValue(*r.Humidity))
+			}
+		}
+	}
+	return []*metricFamily{temps, hums}
+}
+
+// ExpositionText formats readings, keyed by device, as Prometheus text
+// exposition format. Devices are emitted in sorted order.
+func ExpositionText(readings map[string][]Reading) string {
+	var b strings.Builder
+	for _, mf := range readingFamilies(readings) {
+		if mf.samples.Len() == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "# HELP %s %s\n", mf.name, mf.help)
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", mf.name)
+		b.WriteString(mf.samples.String())
+	}
+	return b.String()
+}
+
+// OpenMetricsText is ExpositionText in the OpenMetrics text format, with
+// unit metadata and the terminating "# EOF".
+func OpenMetricsText(readings map[string][]Reading) string {
+	var b strings.Builder
+	for _, mf := range readingFamilies(readings) {
+		if mf.samples.Len() == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", mf.name)
+		fmt.Fprintf(&b, "# UNIT %s %s\n", mf.name, mf.unit)
+		fmt.Fprintf(&b, "# HELP %s %s\n", mf.name, mf.help)
+		b.WriteString(mf.samples.String())
+	}
+	b.WriteString("# EOF\n")
+	return b.String()
+}
@@ -0,0 +1,50 @@
+package temperedgo
+
+import (
+	"testing"
+)
+
+func TestExpositionLabelsSanitiseTags(t *testing.T) {
+	r := Reading{SensorNum: 1, Tags: map[string]string{
+		"a-b":    "1",
+		"a_b":    "2",
+		"a_b_2":  "3",
+		"__x":    "4",
+		"1y":     "5",
+		"device": "6",
+	}}
+	want := `device="/dev/fake0",sensor="1",_1y="5",tag__x="4",a_b="1",a_b_2="2",a_b_2_2="3"`
+	if got := expositionLabels("/dev/fake0", r); got != want {
+		t.Errorf("expositionLabels() = %s, want %s", got, want)
+	}
+}
+
+func TestExpositionText(t *testing.T) {
+	temp, hum := 21.5, 40.0
+	readings := map[string][]Reading{
+		"/dev/b": {{SensorNum: 0, Temperature: &temp}},
+		"/dev/a": {{SensorNum: 0, Label: `say "hi"`, Temperature: &temp, Humidity: &hum}},
+	}
+
+	want := `# HELP tempered_temperature_celsius Sensor temperature in degrees Celsius.
+# TYPE tempered_temperature_celsius gauge
+tempered_temperature_celsius{device="/dev/a",sensor="0",label="say \"hi\""} 21.5
+tempered_temperature_celsius{device="/dev/b",sensor="0"} 21.5
+# HELP tempered_humidity_percent Sensor relative humidity in percent.
+# TYPE tempered_humidity_percent gauge
+tempered_humidity_percent{device="/dev/a",sensor="0",label="say \"hi\""} 40
+`
+	if got := ExpositionText(readings); got != want {
+		t.Errorf("ExpositionText =\n%s\nwant\n%s", got, want)
+	}
+
+	wantOM := `# TYPE tempered_temperature_celsius gauge
+# UNIT tempered_temperature_celsius celsius
+# HELP tempered_temperature_celsius Sensor temperature in degrees Celsius.
+tempered_temperature_celsius{device="/dev/b",sensor="0"} 21.5
+# EOF
+`
+	if got := OpenMetricsText(map[string][]Reading{"/dev/b": readings["/dev/b"]}); got != wantOM {
+		t.Errorf("OpenMetricsText =\n%s\nwant\n%s", got, wantOM)
+	}
+}
@@ -0,0 +1,247 @@
+package temperedgo
+
+import (
+	"runtime"
+	"sync"
+)
+
+// FakeSensor is a simulated sensor on a FakeDevice. Temperature and
+// Humidity are only reported if Type includes them.
+type FakeSensor struct {
+	Type        TemperedSensorType
+	Temperature float64
+	Humidity    float64
+}
+
+// FakeDevice is a simulated device attached to a FakeBackend. OpenErr, if
+// set, is returned when the device is opened, FailUpdate makes every update
+// fail and FailRead every temperature and humidity read. Disconnected
+// simulates the device being unplugged while open: it reports no sensors
+// and every operation on it fails.
+type FakeDevice struct {
+	Path            string
+	TypeName        string
+	VendorId        uint
+	ProductId       uint
+	InterfaceNumber int
+	Manufacturer    string
+	Product         string
+	Sensors         []FakeSensor
+
+	// Script, if set, scripts the values successive updates read: each
+	// update takes Script[0] as the sensor values and drops it, except that
+	// the last entry is kept and repeats. Sensors still sets the sensor
+	// count and types.
+	Script [][]FakeSensor
+
+	// Link, if set, is reported by LinkStatus.
+	Link *LinkStatus
+
+	// Commands records every report sent with SendCommand, and CommandErr,
+	// if set, makes sending fail.
+	Commands   [][]byte
+	CommandErr error
+
+	OpenErr      error
+	FailUpdate   bool
+	FailRead     bool
+	Disconnected bool
+}
+
+// FakeBackend is a Backend that simulates devices in memory, for testing
+// code built on this package without hardware. The error fields make the
+// corresponding Backend call fail as the cgo backend does: with a
+// TemperedError naming the call's OP_ and ERR_*_FAILED sentinel, carrying
+// the field's error text as its Message. Fields, including those of the devices,
+// may be changed while the backend is in use as long as Lock and Unlock are
+// held around the change.
+type FakeBackend struct {
+	sync.Mutex
+
+	InitErr      error
+	ExitErr      error
+	EnumerateErr error
+	Devices      []*FakeDevice
+
+	open int
+}
+
+// OpenHandles returns how many device handles are open, for checking that
+// code closes every device it opens.
+func (b *FakeBackend) OpenHandles() int {
+	b.Lock()
+	defer b.Unlock()
+	return b.open
+}
+
+func (b *FakeBackend) Init() error {
+	b.Lock()
+	defer b.Unlock()
+	return fakeError(OP_INIT, ERR_INIT_FAILED, b.InitErr)
+}
+
+func (b *FakeBackend) Exit() error {
+	b.Lock()
+	defer b.Unlock()
+	return fakeError(OP_EXIT, ERR_EXIT_FAILED, b.ExitErr)
+}
+
+func (b *FakeBackend) Enumerate() ([]TemperedDevice, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.EnumerateErr != nil {
+		return nil, fakeError(OP_ENUMERATE, ERR_ENUMERATE_FAILED, b.EnumerateErr)
+	}
+	tds := make([]TemperedDevice, 0, len(b.Devices))
+	for _, fd := range b.Devices {
+		tds = append(tds, TemperedDevice{
+			Path:            fd.Path,
+			TypeName:        fd.TypeName,
+			VendorId:        fd.VendorId,
+			ProductId:       fd.ProductId,
+			InterfaceNumber: fd.InterfaceNumber,
+		})
+	}
+	return tds, nil
+}
+
+func (b *FakeBackend) Open(td *TemperedDevice) (DeviceHandle, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	for _, fd := range b.Devices {
+		if fd.Path != td.Path {
+			continue
+		}
+		if fd.OpenErr != nil {
+			return nil, fakeError(OP_OPEN, ERR_OPEN_FAILED, fd.OpenErr)
+		}
+		b.open++
+		// As with the cgo backend, a handle dropped without being closed
+		// is closed when it is collected.
+		h := &fakeHandle{backend: b, device: fd}
+		runtime.SetFinalizer(h, (*fakeHandle).Close)
+		return h, nil
+	}
+	return nil, &TemperedError{Op: OP_OPEN, Message: "fake: no device at " + td.Path, Err: ERR_OPEN_FAILED}
+}
+
+// fakeError reports err, if set, as the cgo backend reports a failed op.
+func fakeError(op string, sentinel, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &TemperedError{Op: op, Message: err.Error(), Err: sentinel}
+}
+
+// fakeHandle caches the sensor values on ReadSensors, as libtempered does.
+type fakeHandle struct {
+	backend *FakeBackend
+	device  *FakeDevice
+	values  []FakeSensor
+	closed  bool
+}
+
+func (h *fakeHandle) Close() {
+	runtime.SetFinalizer(h, nil)
+	h.backend.Lock()
+	defer h.backend.Unlock()
+	if !h.closed {
+		h.closed = true
+		h.backend.open--
+	}
+}
+
+func (h *fakeHandle) sendCommand(data []byte) error {
+	h.backend.Lock()
+	defer h.backend.Unlock()
+	if h.device.Disconnected {
+		return &TemperedError{Op: OP_SEND_COMMAND, Message: "device disconnected", Err: ERR_COMMAND_FAILED}
+	}
+	if h.device.CommandErr != nil {
+		return h.device.CommandErr
+	}
+	h.device.Commands = append(h.device.Commands, append([]byte(nil), data...))
+	return nil
+}
+
+func (h *fakeHandle) manufacturer() string {
+	h.backend.Lock()
+	defer h.backend.Unlock()
+	return h.device.Manufacturer
+}
+
+func (h *fakeHandle) product() string {
+	h.backend.Lock()
+	defer h.backend.Unlock()
+	return h.device.Product
+}
+
+func (h *fakeHandle) linkStatus() (LinkStatus, bool) {
+	h.backend.Lock()
+	defer h.backend.Unlock()
+	if h.device.Link == nil {
+		return LinkStatus{}, false
+	}
+	return *h.device.Link, true
+}
+
+func (h *fakeHandle) SensorCount() int {
+	h.backend.Lock()
+	defer h.backend.Unlock()
+	if h.device.Disconnected {
+		return 0
+	}
+	return len(h.device.Sensors)
+}
+
+func (h *fakeHandle) SensorType(sensorNum int) TemperedSensorType {
+	h.backend.Lock()
+	defer h.backend.Unlock()
+	if sensorNum < 0 || sensorNum >= len(h.device.Sensors) {
+		return 0
+	}
+	return h.device.Sensors[sensorNum].Type
+}
+
+func (h *fakeHandle) ReadSensors() bool {
+	h.backend.Lock()
+	defer h.backend.Unlock()
+	if h.device.FailUpdate || h.device.Disconnected {
+		return false
+	}
+	values := h.device.Sensors
+	if len(h.device.Script) > 0 {
+		values = h.device.Script[0]
+		if len(h.device.Script) > 1 {
+			h.device.Script = h.device.Script[1:]
+		}
+	}
+	h.values = append([]FakeSensor(nil), values...)
+	return true
+}
+
+func (h *fakeHandle) Temperature(sensorNum int) (float64, bool) {
+	h.backend.Lock()
+	defer h.backend.Unlock()
+	if h.device.FailRead || h.device.Disconnected {
+		return 0, false
+	}
+	if sensorNum < 0 || sensorNum >= len(h.values) || !h.values[sensorNum].Type.IsType(TEMPERED_SENSOR_TYPE_TEMPERATURE) {
+		return 0, false
+	}
+	return h.values[sensorNum].Temperature, true
+}
+
+func (h *fakeHandle) Humidity(sensorNum int) (float64, bool) {
+	h.backend.Lock()
+	defer h.backend.Unlock()
+	if h.device.FailRead || h.device.Disconnected {
+		return 0, false
+	}
+	if sensorNum < 0 || sensorNum >= len(h.values) || !h.values[sensorNum].Type.IsType(TEMPERED_SENSOR_TYPE_HUMIDITY) {
+		return 0, false
+	}
+	return h.values[sensorNum].Humidity, true
+}
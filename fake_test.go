@@ -0,0 +1,66 @@
+package temperedgo
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+// openFake inits a Tempered on fb and opens its first device, undoing both
+// when the test ends.
+func openFake(t *testing.T, fb *FakeBackend) *TemperedDevice {
+	t.Helper()
+	tm := &Tempered{Backend: fb}
+	if err := tm.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	t.Cleanup(func() { tm.Exit() })
+
+	tds, err := tm.DeviceList()
+	if err != nil {
+		t.Fatalf("DeviceList: %v", err)
+	}
+	if len(tds) == 0 {
+		t.Fatal("DeviceList found no devices")
+	}
+	td := &tds[0]
+	if err := td.Open(); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { td.Close() })
+	return td
+}
+
+func TestDroppedHandleIsFinalized(t *testing.T) {
+	fb := &FakeBackend{Devices: []*FakeDevice{{
+		Path:    "/dev/fake0",
+		Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_TEMPERATURE}},
+	}}}
+	tm := &Tempered{Backend: fb}
+	if err := tm.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer tm.Exit()
+
+	func() {
+		tds, err := tm.DeviceList()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tds[0].Open(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if n := fb.OpenHandles(); n != 1 {
+		t.Fatalf("OpenHandles() = %d after Open, want 1", n)
+	}
+
+	// Finalizers run after a collection, on their own goroutine.
+	for i := 0; i < 50 && fb.OpenHandles() != 0; i++ {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := fb.OpenHandles(); n != 0 {
+		t.Errorf("OpenHandles() = %d after dropping the device, want 0", n)
+	}
+}
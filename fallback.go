@@ -0,0 +1,67 @@
+package temperedgo
+
+import (
+	"errors"
+	"sync"
+)
+
+// FallbackSensor reads from the first of its sensors that reads successfully,
+// so a secondary can stand in for a failed primary. Sensors that don't
+// support a measurement are skipped for it.
+type FallbackSensor struct {
+	sensors []*TemperedSensor
+
+	lock sync.Mutex
+	last *TemperedSensor
+}
+
+// NewFallbackSensor wraps sensors, tried in the order given.
+func NewFallbackSensor(sensors ...*TemperedSensor) *FallbackSensor {
+	return &FallbackSensor{sensors: sensors}
+}
+
+// Source returns the sensor the most recent successful read came from, or
+// nil if there hasn't been one.
+func (fs *FallbackSensor) Source() *TemperedSensor {
+	fs.lock.Lock()
+	defer fs.lock.Unlock()
+
+	return fs.last
+}
+
+// read tries each sensor supporting typ in turn. If all fail, the error
+// joins each sensor's.
+func (fs *FallbackSensor) read(typ TemperedSensorType, f func(ts *TemperedSensor) (float64, error)) (float64, error) {
+	var errs []error
+	for _, ts := range fs.sensors {
+		if !ts.TypeMask.IsType(typ) {
+			continue
+		}
+		val, err := f(ts)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		fs.lock.Lock()
+		fs.last = ts
+		fs.lock.Unlock()
+		return val, nil
+	}
+	if len(errs) == 0 {
+		return 0, ERR_UNSUPPORTED_MEASUREMENT
+	}
+	return 0, errors.Join(errs...)
+}
+
+func (fs *FallbackSensor) Temperature() (Celsius, error) {
+	val, err := fs.read(TEMPERED_SENSOR_TYPE_TEMPERATURE, func(ts *TemperedSensor) (float64, error) {
+		val, err := ts.Temperature()
+		return val.Float64(), err
+	})
+	return Celsius(val), err
+}
+
+func (fs *FallbackSensor) Humidity() (float64, error) {
+	return fs.read(TEMPERED_SENSOR_TYPE_HUMIDITY, (*TemperedSensor).Humidity)
+}
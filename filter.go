@@ -0,0 +1,54 @@
+package temperedgo
+
+import (
+	"sort"
+)
+
+// DeviceFilter selects devices by their enumerated metadata. Zero-valued
+// fields match anything, so the zero DeviceFilter matches every device.
+type DeviceFilter struct {
+	VendorId  uint
+	ProductId uint
+	TypeName  string
+	Path      string
+}
+
+func (f DeviceFilter) Matches(td TemperedDevice) bool {
+	if f.VendorId != 0 && f.VendorId != td.VendorId {
+		return false
+	}
+	if f.ProductId != 0 && f.ProductId != td.ProductId {
+		return false
+	}
+	if f.TypeName != "" && f.TypeName != td.TypeName {
+		return false
+	}
+	if f.Path != "" && f.Path != td.Path {
+		return false
+	}
+	return true
+}
+
+func (f DeviceFilter) Filter(tds []TemperedDevice) []TemperedDevice {
+	matched := []TemperedDevice{}
+	for _, td := range tds {
+		if f.Matches(td) {
+			matched = append(matched, td)
+		}
+	}
+	return matched
+}
+
+// GroupByType groups devices by TypeName, each group sorted by Path.
+func GroupByType(devs []TemperedDevice) map[string][]TemperedDevice {
+	groups := make(map[string][]TemperedDevice)
+	for _, td := range devs {
+		groups[td.TypeName] = append(groups[td.TypeName], td)
+	}
+	for _, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool {
+			return group[i].Path < group[j].Path
+		})
+	}
+	return groups
+}
@@ -0,0 +1,130 @@
+package temperedgo
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"time"
+)
+
+// The flat encoding is a fixed-layout, little-endian format that can be
+// read in place, for instance from an mmapped file, without a decoding
+// library:
+//
+//	header, 16 bytes:
+//	  0  magic "TMPF"
+//	  4  uint16 version (1)
+//	  6  uint16 record size (48)
+//	  8  uint32 record count
+//	  12 uint32 offset of the string table from the start
+//	records, 48 bytes each, from offset 16:
+//	  0  int64   time, Unix nanoseconds
+//	  8  float64 temperature, °C
+//	  16 float64 humidity, %RH
+//	  24 uint32  device ID offset into the string table
+//	  28 uint32  device ID length
+//	  32 uint32  label offset into the string table
+//	  36 uint32  label length
+//	  40 int32   sensor number
+//	  44 uint8   sensor type mask
+//	  45 uint8   valid mask: which of temperature and humidity are set
+//	  46 uint8   role
+//	  47 uint8   reserved, zero
+//	string table: the UTF-8 strings the records refer to, back to back
+const (
+	flatMagic      = "TMPF"
+	flatVersion    = 1
+	flatHeaderSize = 16
+	flatRecordSize = 48
+)
+
+var errFlatInvalid = errors.New("tempered: invalid flat encoding")
+
+// MarshalFlat encodes readings in the flat encoding.
+func MarshalFlat(readings []Reading) []byte {
+	var strs []byte
+	offsets := map[string]uint32{}
+	intern := func(s string) uint32 {
+		if off, ok := offsets[s]; ok {
+			return off
+		}
+		off := uint32(len(strs))
+		strs = append(strs, s...)
+		offsets[s] = off
+		return off
+	}
+
+	tableOff := flatHeaderSize + flatRecordSize*len(readings)
+	buf := make([]byte, tableOff)
+	copy(buf, flatMagic)
+	le := binary.LittleEndian
+	le.PutUint16(buf[4:], flatVersion)
+	le.PutUint16(buf[6:], flatRecordSize)
+	le.PutUint32(buf[8:], uint32(len(readings)))
+	le.PutUint32(buf[12:], uint32(tableOff))
+
+	for n, r := range readings {
+		rec := buf[flatHeaderSize+n*flatRecordSize:]
+		vr := r.Values()
+		le.PutUint64(rec[0:], uint64(r.Time.UnixNano()))
+		le.PutUint64(rec[8:], math.Float64bits(vr.Temperature))
+		le.PutUint64(rec[16:], math.Float64bits(vr.Humidity))
+		le.PutUint32(rec[24:], intern(r.DeviceID))
+		le.PutUint32(rec[28:], uint32(len(r.DeviceID)))
+		le.PutUint32(rec[32:], intern(r.Label))
+		le.PutUint32(rec[36:], uint32(len(r.Label)))
+		le.PutUint32(rec[40:], uint32(int32(r.SensorNum)))
+		rec[44] = byte(r.Type)
+		rec[45] = byte(vr.Valid)
+		rec[46] = byte(r.Role)
+	}
+	return append(buf, strs...)
+}
+
+// UnmarshalFlat decodes data produced by MarshalFlat.
+func UnmarshalFlat(data []byte) ([]Reading, error) {
+	le := binary.LittleEndian
+	if len(data) < flatHeaderSize || string(data[:4]) != flatMagic || le.Uint16(data[4:]) != flatVersion {
+		return nil, errFlatInvalid
+	}
+	recSize := int(le.Uint16(data[6:]))
+	count := int(le.Uint32(data[8:]))
+	tableOff := int(le.Uint32(data[12:]))
+	if recSize < flatRecordSize || tableOff > len(data) || flatHeaderSize+recSize*count > tableOff {
+		return nil, errFlatInvalid
+	}
+	table := data[tableOff:]
+	str := func(off, n uint32) (string, error) {
+		if uint64(off)+uint64(n) > uint64(len(table)) {
+			return "", errFlatInvalid
+		}
+		return string(table[off : off+n]), nil
+	}
+
+	rs := make([]Reading, 0, count)
+	for n := 0; n < count; n++ {
+		rec := data[flatHeaderSize+n*recSize:]
+		deviceID, err := str(le.Uint32(rec[24:]), le.Uint32(rec[28:]))
+		if err != nil {
+			return nil, err
+		}
+		label, err := str(le.Uint32(rec[32:]), le.Uint32(rec[36:]))
+		if err != nil {
+			return nil, err
+		}
+		vr := ValueReading{
+			Time:        time.Unix(0, int64(le.Uint64(rec[0:]))),
+			DeviceID:    deviceID,
+			SensorNum:   int(int32(le.Uint32(rec[40:]))),
+			Role:        SensorRole(rec[46]),
+			Label:       label,
+			Valid:       TemperedSensorType(rec[45]),
+			Temperature: math.Float64frombits(le.Uint64(rec[8:])),
+			Humidity:    math.Float64frombits(le.Uint64(rec[16:])),
+		}
+		r := vr.Reading()
+		r.Type = TemperedSensorType(rec[44])
+		rs = append(rs, r)
+	}
+	return rs, nil
+}
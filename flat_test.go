@@ -0,0 +1,53 @@
+package temperedgo
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFlatRoundTrip(t *testing.T) {
+	temp, hum := 21.5, 40.25
+	at := time.Unix(1700000000, 123456789)
+	rs := []Reading{
+		{Time: at, DeviceID: "/dev/a", SensorNum: 0, Label: "inside", Type: TEMPERED_SENSOR_TYPE_ALL, Temperature: &temp, Humidity: &hum},
+		{Time: at, DeviceID: "/dev/a", SensorNum: 1, Type: TEMPERED_SENSOR_TYPE_TEMPERATURE, Temperature: &temp},
+		{Time: at.Add(time.Second), DeviceID: "/dev/b", SensorNum: -1, Label: "inside"},
+	}
+
+	got, err := UnmarshalFlat(MarshalFlat(rs))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(rs) {
+		t.Fatalf("decoded %d readings, want %d", len(got), len(rs))
+	}
+	for n := range rs {
+		if !got[n].Time.Equal(rs[n].Time) {
+			t.Errorf("reading %d time = %v, want %v", n, got[n].Time, rs[n].Time)
+		}
+		got[n].Time = rs[n].Time
+		if !reflect.DeepEqual(got[n], rs[n]) {
+			t.Errorf("reading %d = %+v, want %+v", n, got[n], rs[n])
+		}
+	}
+
+	if rs, err := UnmarshalFlat(MarshalFlat(nil)); err != nil || len(rs) != 0 {
+		t.Errorf("empty round trip = %v, %v; want no readings", rs, err)
+	}
+}
+
+func TestUnmarshalFlatRejectsCorruption(t *testing.T) {
+	temp := 20.0
+	data := MarshalFlat([]Reading{{DeviceID: "/dev/a", Temperature: &temp}})
+	for name, bad := range map[string][]byte{
+		"empty":     nil,
+		"bad magic": append([]byte("XXXX"), data[4:]...),
+		"truncated": data[:len(data)-1],
+		"no table":  data[:flatHeaderSize+flatRecordSize],
+	} {
+		if _, err := UnmarshalFlat(bad); err == nil {
+			t.Errorf("%s: UnmarshalFlat succeeded, want an error", name)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package temperedgo
+
+import (
+	"fmt"
+	"time"
+)
+
+// FreshReading returns readings no older than maxAge. If the values cached by
+// the last Update are fresh enough they are returned as they are, stamped
+// with the time of that update; otherwise the device is updated first. If
+// that update fails, the error wraps both ERR_STALE_READING and the update
+// error.
+func (t *TemperedDevice) FreshReading(maxAge time.Duration) ([]Reading, error) {
+	t.waitWarmUp()
+
+	if err := acquireLib(t.backendOrDefault()); err != nil {
+		return nil, t.deviceError(err)
+	}
+	defer libLock.RUnlock()
+	t.lockDevice()
+	defer t.unlockDevice()
+
+	if !t.lastUpdate.IsZero() && time.Since(t.lastUpdate) <= maxAge {
+		return t.readCached(t.lastUpdate)
+	}
+
+	if err := t.update(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ERR_STALE_READING, err)
+	}
+	time.Sleep(t.ReadDelay)
+	return t.readCached(t.lastUpdate)
+}
+
+// ReadCached returns the result of the last successful ReadAll if it is
+// younger than maxAge, and does a fresh ReadAll otherwise, so that callers
+// reading the same device in quick succession share one USB round trip.
+// Unlike FreshReading, a cache hit doesn't touch the device at all. The
+// cached readings keep the time they were taken. The cache is dropped when
+// the device is closed.
+func (t *TemperedDevice) ReadCached(maxAge time.Duration) ([]Reading, error) {
+	t.waitWarmUp()
+
+	if err := acquireLib(t.backendOrDefault()); err != nil {
+		return nil, t.deviceError(err)
+	}
+	defer libLock.RUnlock()
+	t.lockDevice()
+	defer t.unlockDevice()
+
+	if t.lastRead != nil && time.Since(t.readTime) < maxAge {
+		return cloneReadings(t.lastRead), nil
+	}
+	return t.readAll()
+}
+
+// LastReadTime returns when the reading cached for ReadCached was taken, or
+// the zero time if there is none.
+func (t *TemperedDevice) LastReadTime() time.Time {
+	t.mutex().Lock()
+	defer t.mutex().Unlock()
+	return t.readTime
+}
@@ -0,0 +1,66 @@
+package temperedgo
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// graphiteNode makes s safe as a single node of a Graphite metric path,
+// replacing anything but letters, digits, '-' and '_' with '_'. Device paths
+// such as /dev/hidraw0 become _dev_hidraw0.
+func graphiteNode(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// graphiteTags formats tags as the ";key=value" suffix of a tagged Graphite
+// metric. Graphite forbids ';', '!', '^', '=' and spaces in tag keys, and
+// ';', '~' and spaces in values, so those become '_'.
+func graphiteTags(tags map[string]string) string {
+	var b strings.Builder
+	for _, k := range sortedTags(tags) {
+		key := strings.Map(func(r rune) rune {
+			if strings.ContainsRune(";!^= ", r) {
+				return '_'
+			}
+			return r
+		}, k)
+		val := strings.Map(func(r rune) rune {
+			if r == ';' || r == '~' || r == ' ' {
+				return '_'
+			}
+			return r
+		}, tags[k])
+		fmt.Fprintf(&b, ";%s=%s", key, val)
+	}
+	return b.String()
+}
+
+// WriteGraphite writes readings in the Graphite plaintext protocol, as
+// tempered.<device>.<sensor>.temperature and .humidity lines stamped with
+// t, with the reading's Tags as Graphite tags. Measurements a reading lacks
+// are skipped.
+func WriteGraphite(w io.Writer, readings []Reading, t time.Time) error {
+	for _, r := range readings {
+		prefix := fmt.Sprintf("tempered.%s.%d", graphiteNode(r.DeviceID), r.SensorNum)
+		tags := graphiteTags(r.Tags)
+		if r.Temperature != nil {
+			if _, err := fmt.Fprintf(w, "%s.temperature%s %g %d\n", prefix, tags, *r.Temperature, t.Unix()); err != nil {
+				return err
+			}
+		}
+		if r.Humidity != nil {
+			if _, err := fmt.Fprintf(w, "%s.humidity%s %g %d\n", prefix, tags, *r.Humidity, t.Unix()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
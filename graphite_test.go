@@ -0,0 +1,27 @@
+package temperedgo
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteGraphite(t *testing.T) {
+	temp, hum := 21.5, 40.0
+	rs := []Reading{
+		{DeviceID: "/dev/hidraw0", SensorNum: 0, Temperature: &temp, Humidity: &hum, Tags: map[string]string{"room": "living room", "a=b": "x;y"}},
+		{DeviceID: "/dev/hidraw1", SensorNum: 1, Temperature: &temp},
+		{DeviceID: "/dev/hidraw2"},
+	}
+	var b strings.Builder
+	if err := WriteGraphite(&b, rs, time.Unix(1700000000, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "tempered._dev_hidraw0.0.temperature;a_b=x_y;room=living_room 21.5 1700000000\n" +
+		"tempered._dev_hidraw0.0.humidity;a_b=x_y;room=living_room 40 1700000000\n" +
+		"tempered._dev_hidraw1.1.temperature 21.5 1700000000\n"
+	if got := b.String(); got != want {
+		t.Errorf("WriteGraphite wrote\n%s\nwant\n%s", got, want)
+	}
+}
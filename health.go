@@ -0,0 +1,72 @@
+package temperedgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// humidityOvershoot is how far above 100%RH a sensor in saturated air, such
+// as fog, may read: genuine overshoot that ClampHumidity would remove, not
+// a fault.
+const humidityOvershoot = 5.0
+
+// The plausible ranges are generous bounds around what TEMPer hardware can
+// physically report; anything outside them indicates a wedged or garbled
+// device rather than a real measurement.
+const (
+	plausibleMinTemperature = -60.0
+	plausibleMaxTemperature = 150.0
+	plausibleMinHumidity    = 0.0
+	plausibleMaxHumidity    = 100.0 + humidityOvershoot
+)
+
+func checkPlausible(r Reading) error {
+	if r.Temperature != nil && (*r.Temperature < plausibleMinTemperature || *r.Temperature > plausibleMaxTemperature) {
+		return fmt.Errorf("sensor %d temperature %g°C: %w", r.SensorNum, *r.Temperature, ERR_IMPLAUSIBLE)
+	}
+	if r.Humidity != nil && (*r.Humidity < plausibleMinHumidity || *r.Humidity > plausibleMaxHumidity) {
+		return fmt.Errorf("sensor %d humidity %g%%RH: %w", r.SensorNum, *r.Humidity, ERR_IMPLAUSIBLE)
+	}
+	return nil
+}
+
+// HealthCheck updates the device once and reads every sensor, returning nil
+// only if at least one value was read and all values are plausible. If ctx is
+// done first the error is ctx.Err(), although the native read carries on in
+// the background.
+func (t *TemperedDevice) HealthCheck(ctx context.Context) error {
+	type result struct {
+		rs  []Reading
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		rs, err := t.ReadAll()
+		done <- result{rs, err}
+	}()
+
+	var res result
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case res = <-done:
+	}
+	if res.err != nil {
+		return res.err
+	}
+
+	read := false
+	for _, r := range res.rs {
+		if err := checkPlausible(r); err != nil {
+			return t.deviceError(err)
+		}
+		if r.Temperature != nil || r.Humidity != nil {
+			read = true
+		}
+	}
+	if !read {
+		return t.deviceError(errors.New("no sensor values read"))
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package temperedgo
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestHealthCheckHumidity(t *testing.T) {
+	for _, tc := range []struct {
+		humidity float64
+		wantErr  error
+	}{
+		{50, nil},
+		{100, nil},
+		{102.5, nil}, // fog overshoot
+		{110, ERR_IMPLAUSIBLE},
+		{-1, ERR_IMPLAUSIBLE},
+	} {
+		fb := &FakeBackend{Devices: []*FakeDevice{{
+			Path:    "/dev/fake0",
+			Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_ALL, Temperature: 5, Humidity: tc.humidity}},
+		}}}
+		td := openFake(t, fb)
+		if err := td.HealthCheck(context.Background()); !errors.Is(err, tc.wantErr) {
+			t.Errorf("HealthCheck at %g%%RH = %v, want %v", tc.humidity, err, tc.wantErr)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package temperedgo
+
+import (
+	"errors"
+	"maps"
+	"math"
+	"slices"
+	"sync"
+)
+
+// Bin counts the values in [Low, High).
+type Bin struct {
+	Low   float64
+	High  float64
+	Count int
+}
+
+// Histogram counts one measurement of the readings added to it in bins of
+// a fixed width, aligned to multiples of that width. It is safe for
+// concurrent use.
+type Histogram struct {
+	width       float64
+	measurement TemperedSensorType
+
+	lock   sync.Mutex
+	counts map[int]int
+}
+
+// NewHistogram bins the temperature, or the humidity if measurement is
+// TEMPERED_SENSOR_TYPE_HUMIDITY, in bins width wide, which must be positive
+// and finite.
+func NewHistogram(width float64, measurement TemperedSensorType) (*Histogram, error) {
+	if !(width > 0) || math.IsInf(width, 1) {
+		return nil, errors.New("tempered: histogram bin width must be positive and finite")
+	}
+	return &Histogram{width: width, measurement: measurement, counts: map[int]int{}}, nil
+}
+
+// maxBinIndex bounds the bin numbers counted so that they fit an int on
+// every platform.
+const maxBinIndex = math.MaxInt32
+
+// Add counts r's value, if it has one. NaN and infinite values, and finite
+// ones too large for a bin number, are skipped.
+func (h *Histogram) Add(r Reading) {
+	val := r.Temperature
+	if h.measurement == TEMPERED_SENSOR_TYPE_HUMIDITY {
+		val = r.Humidity
+	}
+	if val == nil || math.IsNaN(*val) || math.IsInf(*val, 0) {
+		return
+	}
+	bin := math.Floor(*val / h.width)
+	if math.Abs(bin) > maxBinIndex {
+		return
+	}
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.counts[int(bin)]++
+}
+
+// Bins returns the occupied bins, lowest first. Empty bins are left out, so
+// consecutive bins need not be adjacent.
+func (h *Histogram) Bins() []Bin {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if len(h.counts) == 0 {
+		return nil
+	}
+	bins := make([]Bin, 0, len(h.counts))
+	for _, n := range slices.Sorted(maps.Keys(h.counts)) {
+		bins = append(bins, Bin{
+			Low:   float64(n) * h.width,
+			High:  float64(n+1) * h.width,
+			Count: h.counts[n],
+		})
+	}
+	return bins
+}
@@ -0,0 +1,47 @@
+package temperedgo
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestHistogram(t *testing.T) {
+	if _, err := NewHistogram(0, TEMPERED_SENSOR_TYPE_TEMPERATURE); err == nil {
+		t.Error("NewHistogram(0) succeeded, want an error")
+	}
+
+	h, err := NewHistogram(0.5, TEMPERED_SENSOR_TYPE_TEMPERATURE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []float64{20.1, 20.4, 20.6, -0.2, 1e6, math.NaN(), math.Inf(1), math.Inf(-1), 1e300} {
+		h.Add(Reading{Temperature: &v})
+	}
+	h.Add(Reading{})
+
+	want := []Bin{
+		{Low: -0.5, High: 0, Count: 1},
+		{Low: 20, High: 20.5, Count: 2},
+		{Low: 20.5, High: 21, Count: 1},
+		{Low: 1e6, High: 1e6 + 0.5, Count: 1},
+	}
+	if got := h.Bins(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Bins() = %v, want %v", got, want)
+	}
+}
+
+func TestHistogramHumidity(t *testing.T) {
+	h, err := NewHistogram(10, TEMPERED_SENSOR_TYPE_HUMIDITY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	temp, hum := 20.0, 55.0
+	h.Add(Reading{Temperature: &temp, Humidity: &hum})
+	h.Add(Reading{Temperature: &temp})
+
+	want := []Bin{{Low: 50, High: 60, Count: 1}}
+	if got := h.Bins(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Bins() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,76 @@
+package temperedgo
+
+import (
+	"sync"
+	"time"
+)
+
+// History keeps the most recent readings, from any number of sensors, in a
+// fixed-size ring buffer. Once full, each Add overwrites the oldest reading.
+// It is safe for concurrent use.
+type History struct {
+	lock     sync.RWMutex
+	readings []Reading
+	next     int
+	full     bool
+}
+
+func NewHistory(capacity int) *History {
+	return &History{readings: make([]Reading, capacity)}
+}
+
+func (h *History) Add(rs ...Reading) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if len(h.readings) == 0 {
+		return
+	}
+	for _, r := range rs {
+		h.readings[h.next] = r
+		h.next = (h.next + 1) % len(h.readings)
+		if h.next == 0 {
+			h.full = true
+		}
+	}
+}
+
+// ordered returns the stored readings oldest first. The caller must hold the
+// lock.
+func (h *History) ordered() []Reading {
+	if !h.full {
+		return append([]Reading(nil), h.readings[:h.next]...)
+	}
+	rs := make([]Reading, 0, len(h.readings))
+	rs = append(rs, h.readings[h.next:]...)
+	return append(rs, h.readings[:h.next]...)
+}
+
+// Since returns the readings taken at or after t, oldest first.
+func (h *History) Since(t time.Time) []Reading {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	rs := []Reading{}
+	for _, r := range h.ordered() {
+		if !r.Time.Before(t) {
+			rs = append(rs, r)
+		}
+	}
+	return rs
+}
+
+// Last returns up to n of the most recently added readings, oldest first.
+func (h *History) Last(n int) []Reading {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	if n <= 0 {
+		return []Reading{}
+	}
+	rs := h.ordered()
+	if n < len(rs) {
+		rs = rs[len(rs)-n:]
+	}
+	return rs
+}
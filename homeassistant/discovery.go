@@ -0,0 +1,142 @@
+// Package homeassistant publishes readings to Home Assistant over MQTT,
+// including the discovery config that makes the sensors appear in Home
+// Assistant without any manual setup.
+package homeassistant
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	temperedgo "github.com/lukegb/tempered-go"
+)
+
+// Publisher is the one MQTT operation needed, so any client library can be
+// adapted to it.
+type Publisher interface {
+	Publish(topic string, retained bool, payload []byte) error
+}
+
+const (
+	DEFAULT_DISCOVERY_PREFIX = "homeassistant"
+	DEFAULT_STATE_PREFIX     = "tempered"
+)
+
+type Discovery struct {
+	Publisher Publisher
+
+	// DiscoveryPrefix and StatePrefix default to DEFAULT_DISCOVERY_PREFIX
+	// and DEFAULT_STATE_PREFIX.
+	DiscoveryPrefix string
+	StatePrefix     string
+}
+
+type deviceInfo struct {
+	Identifiers  []string `json:"identifiers"`
+	Name         string   `json:"name"`
+	Model        string   `json:"model,omitempty"`
+	Manufacturer string   `json:"manufacturer"`
+}
+
+type sensorConfig struct {
+	Name              string     `json:"name"`
+	UniqueID          string     `json:"unique_id"`
+	StateTopic        string     `json:"state_topic"`
+	DeviceClass       string     `json:"device_class"`
+	StateClass        string     `json:"state_class"`
+	UnitOfMeasurement string     `json:"unit_of_measurement"`
+	ValueTemplate     string     `json:"value_template"`
+	Device            deviceInfo `json:"device"`
+}
+
+type sensorState struct {
+	Temperature *float64 `json:"temperature,omitempty"`
+	Humidity    *float64 `json:"humidity,omitempty"`
+}
+
+var unsafeIDChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// nodeID turns a device ID into something usable in MQTT topics and Home
+// Assistant unique IDs.
+func nodeID(deviceID string) string {
+	return "tempered_" + unsafeIDChars.ReplaceAllString(deviceID, "_")
+}
+
+func (d *Discovery) discoveryPrefix() string {
+	if d.DiscoveryPrefix == "" {
+		return DEFAULT_DISCOVERY_PREFIX
+	}
+	return d.DiscoveryPrefix
+}
+
+func (d *Discovery) stateTopic(r temperedgo.Reading) string {
+	prefix := d.StatePrefix
+	if prefix == "" {
+		prefix = DEFAULT_STATE_PREFIX
+	}
+	return fmt.Sprintf("%s/%s/%d/state", prefix, nodeID(r.DeviceID), r.SensorNum)
+}
+
+// Announce publishes a retained discovery config for each temperature and
+// humidity measurement in rs, which should be the readings of td.
+func (d *Discovery) Announce(td temperedgo.TemperedDevice, rs []temperedgo.Reading) error {
+	node := nodeID(td.ID())
+	dev := deviceInfo{
+		Identifiers:  []string{node},
+		Name:         fmt.Sprintf("%s (%s)", td.TypeName, td.Path),
+		Model:        td.TypeName,
+		Manufacturer: "PCsensor",
+	}
+
+	for _, r := range rs {
+		name := fmt.Sprintf("%s sensor %d", td.TypeName, r.SensorNum)
+		if r.Label != "" {
+			name = r.Label
+		}
+		if r.Temperature != nil {
+			if err := d.announce(node, r, dev, name+" temperature", "temperature", temperedgo.MEASUREMENT_UNIT_CELSIUS); err != nil {
+				return err
+			}
+		}
+		if r.Humidity != nil {
+			if err := d.announce(node, r, dev, name+" humidity", "humidity", "%"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *Discovery) announce(node string, r temperedgo.Reading, dev deviceInfo, name, class, unit string) error {
+	objectID := fmt.Sprintf("%d_%s", r.SensorNum, class)
+	payload, err := json.Marshal(sensorConfig{
+		Name:              name,
+		UniqueID:          node + "_" + objectID,
+		StateTopic:        d.stateTopic(r),
+		DeviceClass:       class,
+		StateClass:        "measurement",
+		UnitOfMeasurement: unit,
+		ValueTemplate:     fmt.Sprintf("{{ value_json.%s }}", class),
+		Device:            dev,
+	})
+	if err != nil {
+		return err
+	}
+	topic := fmt.Sprintf("%s/sensor/%s/%s/config", d.discoveryPrefix(), node, objectID)
+	return d.Publisher.Publish(topic, true, payload)
+}
+
+// PublishState publishes each reading to the state topic its discovery
+// config points at.
+func (d *Discovery) PublishState(rs []temperedgo.Reading) error {
+	for _, r := range rs {
+		payload, err := json.Marshal(sensorState{Temperature: r.Temperature, Humidity: r.Humidity})
+		if err != nil {
+			return err
+		}
+		if err := d.Publisher.Publish(d.stateTopic(r), false, payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package temperedgo
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// DiffDeviceLists compares two enumerations by Path, returning the devices
+// only in new as added and those only in old as removed. Each result keeps
+// the order of the list it came from.
+func DiffDeviceLists(old, new []TemperedDevice) (added, removed []TemperedDevice) {
+	oldPaths := make(map[string]bool, len(old))
+	for _, td := range old {
+		oldPaths[td.Path] = true
+	}
+	newPaths := make(map[string]bool, len(new))
+	for _, td := range new {
+		newPaths[td.Path] = true
+	}
+
+	for _, td := range new {
+		if !oldPaths[td.Path] {
+			added = append(added, td)
+		}
+	}
+	for _, td := range old {
+		if !newPaths[td.Path] {
+			removed = append(removed, td)
+		}
+	}
+	return added, removed
+}
+
+type DeviceEventType int
+
+const (
+	DEVICE_ADDED DeviceEventType = iota
+	DEVICE_REMOVED
+)
+
+// DeviceEvent reports a device appearing or disappearing between scans.
+type DeviceEvent struct {
+	Type   DeviceEventType
+	Device TemperedDevice
+}
+
+// Watch enumerates straight away and then every interval until ctx is done,
+// then closes the channel. Devices are identified by Path, so identical
+// models on different ports are tracked separately. Every device found by
+// the first scan is sent as DEVICE_ADDED; after that, events are sent only
+// for changes. An error from the first scan is returned; a later scan that
+// fails is skipped, and the next one is compared against the last good one.
+func (t *Tempered) Watch(ctx context.Context, interval time.Duration) (<-chan DeviceEvent, error) {
+	if interval <= 0 {
+		return nil, errors.New("tempered: watch interval must be positive")
+	}
+	tds, err := t.DeviceList()
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan DeviceEvent)
+	go func() {
+		defer close(ch)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var known []TemperedDevice
+		for {
+			added, removed := DiffDeviceLists(known, tds)
+			known = tds
+			for _, td := range removed {
+				select {
+				case <-ctx.Done():
+					return
+				case ch <- DeviceEvent{Type: DEVICE_REMOVED, Device: td}:
+				}
+			}
+			for _, td := range added {
+				select {
+				case <-ctx.Done():
+					return
+				case ch <- DeviceEvent{Type: DEVICE_ADDED, Device: td}:
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if tds, err = t.DeviceList(); err != nil {
+				tds = known
+			}
+		}
+	}()
+	return ch, nil
+}
@@ -0,0 +1,71 @@
+package temperedgo
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiffDeviceLists(t *testing.T) {
+	a := TemperedDevice{Path: "/dev/a", TypeName: "TEMPer"}
+	b := TemperedDevice{Path: "/dev/b", TypeName: "TEMPer"}
+	c := TemperedDevice{Path: "/dev/c", TypeName: "TEMPerHUM"}
+
+	added, removed := DiffDeviceLists([]TemperedDevice{a, b}, []TemperedDevice{b, c})
+	if !reflect.DeepEqual(added, []TemperedDevice{c}) {
+		t.Errorf("added = %v, want just /dev/c", added)
+	}
+	if !reflect.DeepEqual(removed, []TemperedDevice{a}) {
+		t.Errorf("removed = %v, want just /dev/a", removed)
+	}
+
+	// Identical models are told apart by Path.
+	if added, removed := DiffDeviceLists([]TemperedDevice{a}, []TemperedDevice{a, b}); len(added) != 1 || added[0].Path != "/dev/b" || len(removed) != 0 {
+		t.Errorf("DiffDeviceLists(a, a+b) = %v, %v; want b added and nothing removed", added, removed)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	fb := &FakeBackend{Devices: []*FakeDevice{{Path: "/dev/fake0"}}}
+	tm := &Tempered{Backend: fb}
+	if err := tm.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer tm.Exit()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := tm.Watch(ctx, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	next := func() DeviceEvent {
+		t.Helper()
+		select {
+		case ev := <-events:
+			return ev
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event")
+		}
+		return DeviceEvent{}
+	}
+
+	if ev := next(); ev.Type != DEVICE_ADDED || ev.Device.Path != "/dev/fake0" {
+		t.Errorf("first event = %v %s, want /dev/fake0 added", ev.Type, ev.Device.Path)
+	}
+
+	fb.Lock()
+	fb.Devices = []*FakeDevice{{Path: "/dev/fake1"}}
+	fb.Unlock()
+	if ev := next(); ev.Type != DEVICE_REMOVED || ev.Device.Path != "/dev/fake0" {
+		t.Errorf("second event = %v %s, want /dev/fake0 removed", ev.Type, ev.Device.Path)
+	}
+	if ev := next(); ev.Type != DEVICE_ADDED || ev.Device.Path != "/dev/fake1" {
+		t.Errorf("third event = %v %s, want /dev/fake1 added", ev.Type, ev.Device.Path)
+	}
+
+	cancel()
+	for range events {
+	}
+}
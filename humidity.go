@@ -0,0 +1,169 @@
+package temperedgo
+
+import (
+	"math"
+	"sync"
+)
+
+// Magnus formula coefficients over water (Sonntag 1990), valid for roughly
+// -45°C to 60°C.
+const (
+	magnusA = 17.62
+	magnusB = 243.12
+)
+
+const DEFAULT_CONDENSATION_MARGIN = 2.0
+
+// MagnusSaturationVaporPressure returns the saturation vapour pressure over
+// water in hPa at tempC degrees Celsius using the Magnus formula. It is the
+// default for humidity-derived calculations.
+func MagnusSaturationVaporPressure(tempC float64) float64 {
+	return 6.112 * math.Exp(magnusA*tempC/(magnusB+tempC))
+}
+
+var (
+	svpLock   sync.RWMutex
+	svpFunc   func(tempC float64) float64
+	svpCustom bool
+)
+
+// SetSaturationVaporPressureFunc replaces the saturation vapour pressure
+// formula used by the package's humidity-derived calculations: dew point,
+// condensation risk, absolute humidity and PMV. f takes a temperature in
+// degrees Celsius and returns a pressure in hPa; it must be strictly
+// increasing over the temperatures it is given. Passing nil restores
+// MagnusSaturationVaporPressure.
+func SetSaturationVaporPressureFunc(f func(tempC float64) float64) {
+	svpLock.Lock()
+	defer svpLock.Unlock()
+
+	svpFunc = f
+	svpCustom = f != nil
+}
+
+func saturationVaporPressure() (func(tempC float64) float64, bool) {
+	svpLock.RLock()
+	defer svpLock.RUnlock()
+
+	if !svpCustom {
+		return MagnusSaturationVaporPressure, false
+	}
+	return svpFunc, true
+}
+
+// dewPoint returns the dew point in degrees Celsius for a temperature in
+// degrees Celsius and a relative humidity in percent. The Magnus formula
+// inverts in closed form; a custom formula is inverted by bisection to within
+// a thousandth of a degree. There is no dew point for dry air, so a relative
+// humidity of zero or below is ERR_IMPLAUSIBLE whichever formula is in use.
+func dewPoint(tempC, relHum float64) (float64, error) {
+	if relHum <= 0 {
+		return 0, ERR_IMPLAUSIBLE
+	}
+
+	svp, custom := saturationVaporPressure()
+	if !custom {
+		gamma := math.Log(relHum/100) + magnusA*tempC/(magnusB+tempC)
+		return magnusB * gamma / (magnusA - gamma), nil
+	}
+
+	target := relHum / 100 * svp(tempC)
+	lo, hi := -100.0, tempC
+	if relHum > 100 {
+		hi = tempC + 100
+	}
+	for hi-lo > 0.001 {
+		mid := (lo + hi) / 2
+		if svp(mid) < target {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2, nil
+}
+
+// waterVaporGasConstant is the specific gas constant for water vapour, in
+// J/(kg·K).
+const waterVaporGasConstant = 461.5
+
+// absoluteHumidity returns the water vapour density in g/m³ for a
+// temperature in degrees Celsius and a relative humidity in percent, using
+// the configured saturation vapour pressure formula.
+func absoluteHumidity(tempC, relHum float64) float64 {
+	svp, _ := saturationVaporPressure()
+	pa := relHum / 100 * svp(tempC) * 100
+	return pa / (waterVaporGasConstant * (tempC + 273.15)) * 1000
+}
+
+func (ts *TemperedSensor) temperatureAndHumidity() (float64, float64, error) {
+	if !ts.TypeMask.IsType(TEMPERED_SENSOR_TYPE_ALL) {
+		return 0, 0, ERR_UNSUPPORTED_MEASUREMENT
+	}
+	tempC, err := ts.Temperature()
+	if err != nil {
+		return 0, 0, err
+	}
+	relHum, err := ts.Humidity()
+	if err != nil {
+		return 0, 0, err
+	}
+	return tempC.Float64(), relHum, nil
+}
+
+// DewPoint returns the dew point in degrees Celsius by the Magnus formula,
+// or by inverting the formula set with SetSaturationVaporPressureFunc. The
+// sensor must measure both temperature and humidity, otherwise the error is
+// ERR_UNSUPPORTED_MEASUREMENT. A humidity reading of 0%RH or below is
+// ERR_IMPLAUSIBLE.
+func (ts *TemperedSensor) DewPoint() (float64, error) {
+	tempC, relHum, err := ts.temperatureAndHumidity()
+	if err != nil {
+		return 0, err
+	}
+	return dewPoint(tempC, relHum)
+}
+
+// CondensationRisk reports whether the temperature is within the sensor's
+// CondensationMargin of the dew point, along with the current margin (the
+// temperature minus the dew point) in degrees Celsius. Like DewPoint, it
+// fails with ERR_IMPLAUSIBLE at 0%RH or below.
+func (ts *TemperedSensor) CondensationRisk() (bool, float64, error) {
+	tempC, relHum, err := ts.temperatureAndHumidity()
+	if err != nil {
+		return false, 0, err
+	}
+
+	threshold := ts.CondensationMargin
+	if threshold == 0 {
+		threshold = DEFAULT_CONDENSATION_MARGIN
+	}
+	td, err := dewPoint(tempC, relHum)
+	if err != nil {
+		return false, 0, err
+	}
+	margin := tempC - td
+	return margin <= threshold, margin, nil
+}
+
+// wetBulb is Stull's (2011) empirical wet-bulb temperature for sea-level
+// pressure.
+func wetBulb(tempC, relHum float64) float64 {
+	return tempC*math.Atan(0.151977*math.Sqrt(relHum+8.313659)) +
+		math.Atan(tempC+relHum) - math.Atan(relHum-1.676331) +
+		0.00391838*math.Pow(relHum, 1.5)*math.Atan(0.023101*relHum) -
+		4.686035
+}
+
+// WetBulb returns the wet-bulb temperature in degrees Celsius, by Stull's
+// approximation. It is fitted for 5-99%RH and -20 to 50°C at sea-level
+// pressure, where it is within about -1 to +0.65°C of the psychrometric
+// value (mean absolute error under 0.3°C); outside that range, and at low
+// temperature combined with low humidity, it degrades quickly.
+func (ts *TemperedSensor) WetBulb() (float64, error) {
+	tempC, relHum, err := ts.temperatureAndHumidity()
+	if err != nil {
+		return 0, err
+	}
+	return wetBulb(tempC, relHum), nil
+}
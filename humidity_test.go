@@ -0,0 +1,64 @@
+package temperedgo
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestDewPoint(t *testing.T) {
+	tests := []struct {
+		tempC, relHum, want float64
+	}{
+		{20, 50, 9.26},
+		{25, 60, 16.69},
+		{30, 80, 26.17},
+		{10, 30, -6.81},
+		{0, 100, 0},
+	}
+	for _, custom := range []bool{false, true} {
+		if custom {
+			// The same formula supplied as a custom one takes the bisection
+			// path, which must agree with the closed form.
+			SetSaturationVaporPressureFunc(MagnusSaturationVaporPressure)
+			defer SetSaturationVaporPressureFunc(nil)
+		}
+		for _, tt := range tests {
+			got, err := dewPoint(tt.tempC, tt.relHum)
+			if err != nil {
+				t.Errorf("custom=%v: dewPoint(%v, %v): %v", custom, tt.tempC, tt.relHum, err)
+				continue
+			}
+			if math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("custom=%v: dewPoint(%v, %v) = %v, want %v", custom, tt.tempC, tt.relHum, got, tt.want)
+			}
+		}
+		for _, relHum := range []float64{0, -5} {
+			if _, err := dewPoint(20, relHum); !errors.Is(err, ERR_IMPLAUSIBLE) {
+				t.Errorf("custom=%v: dewPoint(20, %v) error = %v, want ERR_IMPLAUSIBLE", custom, relHum, err)
+			}
+		}
+	}
+}
+
+func TestCondensationRiskDryAir(t *testing.T) {
+	fb := &FakeBackend{Devices: []*FakeDevice{{
+		Path:    "/dev/fake0",
+		Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_ALL, Temperature: 20, Humidity: 0}},
+	}}}
+	td := openFake(t, fb)
+	if err := td.Update(); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	sensors, err := td.Sensors()
+	if err != nil {
+		t.Fatalf("Sensors: %v", err)
+	}
+
+	if _, err := sensors[0].DewPoint(); !errors.Is(err, ERR_IMPLAUSIBLE) {
+		t.Errorf("DewPoint() error = %v, want ERR_IMPLAUSIBLE", err)
+	}
+	if _, _, err := sensors[0].CondensationRisk(); !errors.Is(err, ERR_IMPLAUSIBLE) {
+		t.Errorf("CondensationRisk() error = %v, want ERR_IMPLAUSIBLE", err)
+	}
+}
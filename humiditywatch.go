@@ -0,0 +1,75 @@
+package temperedgo
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+const minHumidityWatchInterval = time.Second
+
+// WatchHumidityChange polls sensor sensorNum and calls cb when its humidity
+// has moved by more than deltaPct percentage points of relative humidity
+// compared with any reading in the last window, passing that earlier value
+// and the new one. The device is polled five times per window, but no more
+// than once a second. After firing, the readings seen so far are forgotten
+// and the watch is quiet for a window, so one noisy spike triggers at most
+// once. Failed reads are skipped. WatchHumidityChange runs until ctx is done
+// and returns ctx.Err().
+func (t *TemperedDevice) WatchHumidityChange(ctx context.Context, sensorNum int, deltaPct float64, window time.Duration, cb func(old, new float64)) error {
+	interval := window / 5
+	if interval < minHumidityWatchInterval {
+		interval = minHumidityWatchInterval
+	}
+	ch, err := t.PollAligned(ctx, interval)
+	if err != nil {
+		return err
+	}
+
+	type sample struct {
+		time time.Time
+		hum  float64
+	}
+	var samples []sample
+	var quietUntil time.Time
+	for res := range ch {
+		if res.Err != nil {
+			continue
+		}
+		var hum *float64
+		for _, r := range res.Readings {
+			if r.SensorNum == sensorNum {
+				hum = r.Humidity
+			}
+		}
+		if hum == nil {
+			continue
+		}
+
+		kept := samples[:0]
+		for _, s := range samples {
+			if res.Time.Sub(s.time) <= window {
+				kept = append(kept, s)
+			}
+		}
+		samples = kept
+
+		if !res.Time.Before(quietUntil) {
+			fired := false
+			var old float64
+			for _, s := range samples {
+				if d := math.Abs(*hum - s.hum); d > deltaPct && (!fired || d > math.Abs(*hum-old)) {
+					old = s.hum
+					fired = true
+				}
+			}
+			if fired {
+				cb(old, *hum)
+				samples = nil
+				quietUntil = res.Time.Add(window)
+			}
+		}
+		samples = append(samples, sample{res.Time, *hum})
+	}
+	return ctx.Err()
+}
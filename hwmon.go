@@ -0,0 +1,60 @@
+package temperedgo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// HwmonFiles formats readings the way the Linux hwmon sysfs interface does:
+// tempN_input in millidegrees Celsius and humidityN_input in milli-percent,
+// where N is the sensor number plus one. Labelled sensors also get a
+// tempN_label/humidityN_label entry. The returned map is keyed by file name.
+func HwmonFiles(readings []Reading) map[string]string {
+	files := make(map[string]string)
+	for _, r := range readings {
+		n := r.SensorNum + 1
+		if r.Temperature != nil {
+			files[fmt.Sprintf("temp%d_input", n)] = fmt.Sprintf("%d\n", toMilli(*r.Temperature))
+			if r.Label != "" {
+				files[fmt.Sprintf("temp%d_label", n)] = r.Label + "\n"
+			}
+		}
+		if r.Humidity != nil {
+			files[fmt.Sprintf("humidity%d_input", n)] = fmt.Sprintf("%d\n", toMilli(*r.Humidity))
+			if r.Label != "" {
+				files[fmt.Sprintf("humidity%d_label", n)] = r.Label + "\n"
+			}
+		}
+	}
+	return files
+}
+
+// WriteHwmonDir writes HwmonFiles(readings) into dir, along with a name file
+// containing name. Each file is replaced atomically so concurrent readers
+// never see a partial value.
+func WriteHwmonDir(dir, name string, readings []Reading) error {
+	files := HwmonFiles(readings)
+	files["name"] = name + "\n"
+
+	for fn, contents := range files {
+		tmp, err := os.CreateTemp(dir, "."+fn)
+		if err != nil {
+			return err
+		}
+		if _, err := tmp.WriteString(contents); err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+			return err
+		}
+		if err := tmp.Close(); err != nil {
+			os.Remove(tmp.Name())
+			return err
+		}
+		if err := os.Rename(tmp.Name(), filepath.Join(dir, fn)); err != nil {
+			os.Remove(tmp.Name())
+			return err
+		}
+	}
+	return nil
+}
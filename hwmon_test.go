@@ -0,0 +1,54 @@
+package temperedgo
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestHwmonFiles(t *testing.T) {
+	temp, hum := 21.5, 40.0
+	neg := -0.0015
+	rs := []Reading{
+		{SensorNum: 0, Label: "inside", Temperature: &temp, Humidity: &hum},
+		{SensorNum: 1, Temperature: &neg},
+	}
+	want := map[string]string{
+		"temp1_input":     "21500\n",
+		"temp1_label":     "inside\n",
+		"humidity1_input": "40000\n",
+		"humidity1_label": "inside\n",
+		"temp2_input":     "-2\n",
+	}
+	if got := HwmonFiles(rs); !reflect.DeepEqual(got, want) {
+		t.Errorf("HwmonFiles = %v, want %v", got, want)
+	}
+}
+
+func TestWriteHwmonDir(t *testing.T) {
+	dir := t.TempDir()
+	temp := 21.5
+	if err := WriteHwmonDir(dir, "tempered", []Reading{{Temperature: &temp}}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	// No temporary files are left behind.
+	if !reflect.DeepEqual(names, []string{"name", "temp1_input"}) {
+		t.Errorf("directory holds %v, want just name and temp1_input", names)
+	}
+	for fn, want := range map[string]string{"name": "tempered\n", "temp1_input": "21500\n"} {
+		got, err := os.ReadFile(filepath.Join(dir, fn))
+		if err != nil || string(got) != want {
+			t.Errorf("%s = %q, %v; want %q", fn, got, err, want)
+		}
+	}
+}
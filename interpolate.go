@@ -0,0 +1,64 @@
+package temperedgo
+
+import (
+	"sort"
+	"time"
+)
+
+// Interpolate resamples a single sensor's readings onto an even grid of
+// step, starting at the earliest reading, by linear interpolation between
+// the readings either side of each grid point. Grid points in a gap between
+// readings longer than maxGap are left out, so long outages stay visible as
+// holes; a maxGap of zero or less interpolates across any gap. A measurement
+// is only interpolated when both neighbouring readings have it. The other
+// fields are taken from the earlier neighbour.
+func Interpolate(readings []Reading, step, maxGap time.Duration) []Reading {
+	if len(readings) == 0 || step <= 0 {
+		return nil
+	}
+
+	rs := append([]Reading(nil), readings...)
+	sort.SliceStable(rs, func(i, j int) bool {
+		return rs[i].Time.Before(rs[j].Time)
+	})
+
+	out := []Reading{}
+	start, end := rs[0].Time, rs[len(rs)-1].Time
+	i := 0
+	for at := start; !at.After(end); at = at.Add(step) {
+		for i < len(rs)-2 && rs[i+1].Time.Before(at) {
+			i++
+		}
+		a, b := rs[i], rs[i]
+		if i+1 < len(rs) {
+			b = rs[i+1]
+		}
+		if at.Equal(b.Time) {
+			a = b
+		}
+
+		gap := b.Time.Sub(a.Time)
+		if maxGap > 0 && gap > maxGap {
+			continue
+		}
+
+		var frac float64
+		if gap > 0 {
+			frac = float64(at.Sub(a.Time)) / float64(gap)
+		}
+		r := a
+		r.Time = at
+		r.Temperature = lerp(a.Temperature, b.Temperature, frac)
+		r.Humidity = lerp(a.Humidity, b.Humidity, frac)
+		out = append(out, r)
+	}
+	return out
+}
+
+func lerp(a, b *float64, frac float64) *float64 {
+	if a == nil || b == nil {
+		return nil
+	}
+	val := *a + (*b-*a)*frac
+	return &val
+}
@@ -0,0 +1,63 @@
+package temperedgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInterpolate(t *testing.T) {
+	start := time.Unix(0, 0)
+	reading := func(offset time.Duration, temp float64) Reading {
+		return Reading{Time: start.Add(offset), Temperature: &temp}
+	}
+	// Out of order on purpose, with a ten-minute outage after 2m.
+	rs := []Reading{
+		reading(2*time.Minute, 22),
+		reading(0, 20),
+		reading(12*time.Minute, 32),
+	}
+
+	got := Interpolate(rs, time.Minute, 5*time.Minute)
+	want := map[time.Duration]float64{0: 20, time.Minute: 21, 2 * time.Minute: 22, 12 * time.Minute: 32}
+	if len(got) != len(want) {
+		t.Fatalf("Interpolate gave %d points, want %d: %v", len(got), len(want), got)
+	}
+	for _, r := range got {
+		offset := r.Time.Sub(start)
+		w, ok := want[offset]
+		if !ok {
+			t.Errorf("point at %v falls in the outage", offset)
+			continue
+		}
+		if r.Temperature == nil || *r.Temperature != w {
+			t.Errorf("point at %v = %v, want %v", offset, r.Temperature, w)
+		}
+	}
+
+	// Without a maxGap the outage is bridged.
+	if got := Interpolate(rs, time.Minute, 0); len(got) != 13 || *got[7].Temperature != 27 {
+		t.Errorf("Interpolate with no maxGap gave %d points, want 13 with 27 at 7m", len(got))
+	}
+	if got := Interpolate(rs, 0, 0); got != nil {
+		t.Errorf("Interpolate with a zero step = %v, want nil", got)
+	}
+}
+
+func TestInterpolateNeedsBothNeighbours(t *testing.T) {
+	start := time.Unix(0, 0)
+	temp, hum := 20.0, 50.0
+	rs := []Reading{
+		{Time: start, Temperature: &temp, Humidity: &hum},
+		{Time: start.Add(2 * time.Minute), Temperature: &temp},
+	}
+	got := Interpolate(rs, time.Minute, 0)
+	if len(got) != 3 {
+		t.Fatalf("Interpolate gave %d points, want 3", len(got))
+	}
+	if got[1].Humidity != nil {
+		t.Errorf("humidity interpolated to %v although only one neighbour has it", *got[1].Humidity)
+	}
+	if got[1].Temperature == nil || *got[1].Temperature != 20 {
+		t.Errorf("temperature at 1m = %v, want 20", got[1].Temperature)
+	}
+}
@@ -0,0 +1,47 @@
+package temperedgo
+
+// DeviceInventory describes what happened when a device was briefly opened.
+// Err is the first open, sensor count or update error, if any.
+type DeviceInventory struct {
+	Device      TemperedDevice
+	Openable    bool
+	SensorCount int
+	Err         error
+}
+
+func inventoryDevice(td TemperedDevice) DeviceInventory {
+	inv := DeviceInventory{Device: td}
+
+	dev := td
+	if err := dev.Open(); err != nil {
+		inv.Err = err
+		return inv
+	}
+	defer dev.Close()
+	inv.Openable = true
+
+	count, err := dev.SensorCount()
+	if err != nil {
+		inv.Err = err
+		return inv
+	}
+	inv.SensorCount = count
+
+	inv.Err = dev.Update()
+	return inv
+}
+
+// Inventory enumerates devices and opens, updates and closes each in turn to
+// report whether it is usable and how many sensors it has.
+func (t *Tempered) Inventory() ([]DeviceInventory, error) {
+	tds, err := t.DeviceList()
+	if err != nil {
+		return nil, err
+	}
+
+	invs := make([]DeviceInventory, 0, len(tds))
+	for _, td := range tds {
+		invs = append(invs, inventoryDevice(td))
+	}
+	return invs, nil
+}
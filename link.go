@@ -0,0 +1,35 @@
+package temperedgo
+
+// LinkStatus is the state of a wireless link between a bridge and its
+// sensor. Either field is nil if the device doesn't report it.
+type LinkStatus struct {
+	// SignalPercent is the link quality from 0 to 100.
+	SignalPercent *float64
+	// BatteryPercent is the remote sensor's remaining charge from 0 to 100.
+	BatteryPercent *float64
+}
+
+// LinkStatus reports the wireless link state of the open device. Wired
+// devices, and every device with the cgo backend since libtempered reports
+// no link state, return ERR_NOT_SUPPORTED.
+func (t *TemperedDevice) LinkStatus() (LinkStatus, error) {
+	if err := acquireLib(t.backendOrDefault()); err != nil {
+		return LinkStatus{}, t.deviceError(err)
+	}
+	defer libLock.RUnlock()
+	t.lockDevice()
+	defer t.unlockDevice()
+
+	if t.dev == nil {
+		return LinkStatus{}, t.deviceError(ERR_NOT_OPEN)
+	}
+	lr, ok := t.dev.(linkReporter)
+	if !ok {
+		return LinkStatus{}, t.deviceError(ERR_NOT_SUPPORTED)
+	}
+	status, ok := lr.linkStatus()
+	if !ok {
+		return LinkStatus{}, t.deviceError(ERR_NOT_SUPPORTED)
+	}
+	return status, nil
+}
@@ -0,0 +1,123 @@
+package temperedgo
+
+import (
+	"context"
+	"fmt"
+)
+
+// OpenByPath enumerates devices to find the metadata the native open needs
+// for the device at path, then opens it.
+func OpenByPath(t *Tempered, path string) (*TemperedDevice, error) {
+	tds, err := t.DeviceList()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, td := range tds {
+		if td.Path != path {
+			continue
+		}
+		dev := td
+		if err := dev.Open(); err != nil {
+			return nil, err
+		}
+		return &dev, nil
+	}
+
+	return nil, fmt.Errorf("%s: %w", path, ERR_NO_DEVICE_FOUND)
+}
+
+// OpenByPath is the package-level OpenByPath on t. If no device is at path
+// the error wraps ERR_NO_DEVICE_FOUND.
+func (t *Tempered) OpenByPath(path string) (*TemperedDevice, error) {
+	return OpenByPath(t, path)
+}
+
+// DeviceByPath returns the device at path: one registered with Track if
+// there is one, which may already be open, and otherwise a freshly
+// enumerated, unopened device. It reports false if there is no such device
+// or enumeration fails.
+func (t *Tempered) DeviceByPath(path string) (*TemperedDevice, bool) {
+	t.trackLock.Lock()
+	for _, td := range t.tracked {
+		if td.Path == path {
+			t.trackLock.Unlock()
+			return td, true
+		}
+	}
+	t.trackLock.Unlock()
+
+	td, ok, err := t.Probe(DeviceFilter{Path: path})
+	if err != nil || !ok {
+		return nil, false
+	}
+	return &td, true
+}
+
+// OpenFirst opens the first enumerated device matching filter, returning
+// ERR_NO_DEVICE_FOUND if none do.
+func (t *Tempered) OpenFirst(filter DeviceFilter) (*TemperedDevice, error) {
+	tds, err := t.DeviceList()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, td := range tds {
+		if !filter.Matches(td) {
+			continue
+		}
+		dev := td
+		if err := dev.Open(); err != nil {
+			return nil, err
+		}
+		return &dev, nil
+	}
+
+	return nil, ERR_NO_DEVICE_FOUND
+}
+
+// Probe reports the first enumerated device matching filter without opening
+// it. The bool distinguishes "not present" from an enumeration error.
+func (t *Tempered) Probe(filter DeviceFilter) (TemperedDevice, bool, error) {
+	tds, err := t.DeviceList()
+	if err != nil {
+		return TemperedDevice{}, false, err
+	}
+
+	for _, td := range tds {
+		if filter.Matches(td) {
+			return td, true, nil
+		}
+	}
+	return TemperedDevice{}, false, nil
+}
+
+// OpenableDevices enumerates devices and returns those that can currently be
+// opened, briefly opening and closing each to find out.
+func (t *Tempered) OpenableDevices() ([]TemperedDevice, error) {
+	return t.OpenableDevicesContext(context.Background())
+}
+
+// OpenableDevicesContext is OpenableDevices, giving up when ctx is done so a
+// hanging device can't stall it. The devices confirmed before then are
+// returned along with ctx.Err().
+func (t *Tempered) OpenableDevicesContext(ctx context.Context) ([]TemperedDevice, error) {
+	tds, err := t.DeviceListContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	openable := []TemperedDevice{}
+	for _, td := range tds {
+		dev := td
+		if err := dev.OpenContext(ctx); err != nil {
+			if ctx.Err() != nil {
+				return openable, ctx.Err()
+			}
+			continue
+		}
+		dev.Close()
+		openable = append(openable, td)
+	}
+	return openable, nil
+}
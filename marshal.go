@@ -0,0 +1,84 @@
+package temperedgo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// String identifies the device by path, model and USB IDs, as in
+// "/dev/hidraw0 (TEMPerHUM 413d:2107)".
+func (t TemperedDevice) String() string {
+	return fmt.Sprintf("%s (%s %04x:%04x)", t.Path, t.TypeName, t.VendorId, t.ProductId)
+}
+
+// MarshalJSON encodes the device's identity: its path, model and USB IDs.
+// Its open handle and settings are left out.
+func (t TemperedDevice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Path            string `json:"path"`
+		TypeName        string `json:"type_name"`
+		VendorId        uint   `json:"vendor_id"`
+		ProductId       uint   `json:"product_id"`
+		InterfaceNumber int    `json:"interface_number"`
+	}{t.Path, t.TypeName, t.VendorId, t.ProductId, t.InterfaceNumber})
+}
+
+// String identifies the sensor by device path and number, with its label if
+// it has one, and its measurements, as in
+// "/dev/hidraw0 sensor 0 "office" (temperature+humidity)".
+func (ts *TemperedSensor) String() string {
+	path := ""
+	if ts.device != nil {
+		path = ts.device.Path
+	}
+	if ts.Label != "" {
+		return fmt.Sprintf("%s sensor %d %q (%s)", path, ts.sensorNum, ts.Label, ts.TypeMask)
+	}
+	return fmt.Sprintf("%s sensor %d (%s)", path, ts.sensorNum, ts.TypeMask)
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+type deviceReadingJSON struct {
+	// DeviceID shadows the embedded Reading's: the two are the same except
+	// when Err is set, and then Reading is empty.
+	DeviceID string `json:"device_id"`
+	Path     string `json:"path"`
+	Reading
+	Err string `json:"error,omitempty"`
+}
+
+// MarshalJSON encodes dr as its Reading's fields plus "path", and "error"
+// when Err is set. Without it the embedded Reading's fields would be
+// promoted and Err, being an interface, would encode as {}.
+func (dr DeviceReading) MarshalJSON() ([]byte, error) {
+	return json.Marshal(deviceReadingJSON{
+		DeviceID: dr.DeviceID,
+		Path:     dr.Path,
+		Reading:  dr.Reading,
+		Err:      errorString(dr.Err),
+	})
+}
+
+// UnmarshalJSON decodes what MarshalJSON produces. An error comes back as
+// its message only, so it no longer matches sentinels with errors.Is.
+func (dr *DeviceReading) UnmarshalJSON(data []byte) error {
+	var j deviceReadingJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+
+	*dr = DeviceReading{DeviceID: j.DeviceID, Path: j.Path, Reading: j.Reading}
+	if j.Err != "" {
+		dr.Err = errors.New(j.Err)
+	} else {
+		dr.Reading.DeviceID = j.DeviceID
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package temperedgo
+
+import (
+	"math"
+)
+
+const (
+	MEASUREMENT_UNIT_CELSIUS           = "°C"
+	MEASUREMENT_UNIT_RELATIVE_HUMIDITY = "%RH"
+)
+
+type Measurement struct {
+	Value float64
+	Unit  string
+}
+
+func (ts *TemperedSensor) TemperatureMeasurement() (Measurement, error) {
+	val, err := ts.Temperature()
+	if err != nil {
+		return Measurement{}, err
+	}
+	return Measurement{Value: val.Float64(), Unit: MEASUREMENT_UNIT_CELSIUS}, nil
+}
+
+func (ts *TemperedSensor) HumidityMeasurement() (Measurement, error) {
+	val, err := ts.Humidity()
+	if err != nil {
+		return Measurement{}, err
+	}
+	return Measurement{Value: val, Unit: MEASUREMENT_UNIT_RELATIVE_HUMIDITY}, nil
+}
+
+// toMilli converts to thousandths, rounding halves away from zero.
+func toMilli(v float64) int {
+	return int(math.Round(v * 1000))
+}
+
+// TemperatureMilliC returns the temperature in thousandths of a degree
+// Celsius, as used by Linux hwmon.
+func (ts *TemperedSensor) TemperatureMilliC() (int, error) {
+	val, err := ts.Temperature()
+	if err != nil {
+		return 0, err
+	}
+	return toMilli(val.Float64()), nil
+}
+
+// HumidityMilliPercent returns the relative humidity in thousandths of a
+// percent, as used by Linux hwmon.
+func (ts *TemperedSensor) HumidityMilliPercent() (int, error) {
+	val, err := ts.Humidity()
+	if err != nil {
+		return 0, err
+	}
+	return toMilli(val), nil
+}
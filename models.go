@@ -0,0 +1,130 @@
+package temperedgo
+
+type SensorRole int
+
+const (
+	TEMPERED_SENSOR_ROLE_UNKNOWN SensorRole = iota
+	TEMPERED_SENSOR_ROLE_INTERNAL
+	TEMPERED_SENSOR_ROLE_EXTERNAL
+)
+
+func (sr SensorRole) String() string {
+	switch sr {
+	case TEMPERED_SENSOR_ROLE_INTERNAL:
+		return "internal"
+	case TEMPERED_SENSOR_ROLE_EXTERNAL:
+		return "external"
+	}
+	return "unknown"
+}
+
+type knownModel struct {
+	// roles is indexed by sensor number.
+	roles []SensorRole
+	// types is every measurement the model's sensors can make.
+	types TemperedSensorType
+}
+
+// knownModels is keyed by the type name libtempered reports. It is
+// best-effort: models missing from it are still fully usable, we just know
+// less about them.
+var knownModels = map[string]knownModel{
+	"TEMPer": {
+		roles: []SensorRole{TEMPERED_SENSOR_ROLE_INTERNAL},
+		types: TEMPERED_SENSOR_TYPE_TEMPERATURE,
+	},
+	"TEMPer1": {
+		roles: []SensorRole{TEMPERED_SENSOR_ROLE_EXTERNAL},
+		types: TEMPERED_SENSOR_TYPE_TEMPERATURE,
+	},
+	"TEMPer1F": {
+		roles: []SensorRole{TEMPERED_SENSOR_ROLE_EXTERNAL},
+		types: TEMPERED_SENSOR_TYPE_TEMPERATURE,
+	},
+	"TEMPer2": {
+		roles: []SensorRole{TEMPERED_SENSOR_ROLE_INTERNAL, TEMPERED_SENSOR_ROLE_EXTERNAL},
+		types: TEMPERED_SENSOR_TYPE_TEMPERATURE,
+	},
+	"TEMPerHUM": {
+		roles: []SensorRole{TEMPERED_SENSOR_ROLE_INTERNAL},
+		types: TEMPERED_SENSOR_TYPE_TEMPERATURE | TEMPERED_SENSOR_TYPE_HUMIDITY,
+	},
+}
+
+// CapabilitiesKnown reports whether TypeName is a model SupportsTemperature
+// and SupportsHumidity know about. For other models they report false.
+func (t *TemperedDevice) CapabilitiesKnown() bool {
+	_, ok := knownModels[t.TypeName]
+	return ok
+}
+
+// SupportsTemperature reports, without opening the device, whether its
+// model is known to measure temperature.
+func (t *TemperedDevice) SupportsTemperature() bool {
+	return knownModels[t.TypeName].types.IsType(TEMPERED_SENSOR_TYPE_TEMPERATURE)
+}
+
+// SupportsHumidity reports, without opening the device, whether its model
+// is known to measure humidity.
+func (t *TemperedDevice) SupportsHumidity() bool {
+	return knownModels[t.TypeName].types.IsType(TEMPERED_SENSOR_TYPE_HUMIDITY)
+}
+
+func sensorRole(typeName string, sensorNum int) SensorRole {
+	model, ok := knownModels[typeName]
+	if !ok || sensorNum < 0 || sensorNum >= len(model.roles) {
+		return TEMPERED_SENSOR_ROLE_UNKNOWN
+	}
+	return model.roles[sensorNum]
+}
+
+// sensorByRole returns the first sensor, by sensor number, that has the given
+// role and supports the measurement in typ.
+func (t *TemperedDevice) sensorByRole(role SensorRole, typ TemperedSensorType) (*TemperedSensor, error) {
+	tsList, err := t.SortedSensors()
+	if err != nil {
+		return nil, err
+	}
+	for _, ts := range tsList {
+		if ts.Role == role && ts.TypeMask.IsType(typ) {
+			return ts, nil
+		}
+	}
+	return nil, t.deviceError(ERR_ROLE_NOT_FOUND)
+}
+
+// TemperatureByRole returns the temperature from the first sensor with the
+// given role, so callers need not depend on how a model numbers its sensors.
+func (t *TemperedDevice) TemperatureByRole(role SensorRole) (Celsius, error) {
+	ts, err := t.sensorByRole(role, TEMPERED_SENSOR_TYPE_TEMPERATURE)
+	if err != nil {
+		return 0, err
+	}
+	return t.Temperature(ts.sensorNum)
+}
+
+// HumidityByRole is TemperatureByRole for relative humidity.
+func (t *TemperedDevice) HumidityByRole(role SensorRole) (float64, error) {
+	ts, err := t.sensorByRole(role, TEMPERED_SENSOR_TYPE_HUMIDITY)
+	if err != nil {
+		return 0, err
+	}
+	return t.Humidity(ts.sensorNum)
+}
+
+// knownIDs are the USB vendor and product IDs libtempered drives.
+var knownIDs = map[quirkKey]bool{
+	{0x1130, 0x660c}: true, // TEMPer
+	{0x0c45, 0x7401}: true, // TEMPer1, TEMPer2 and relatives
+	{0x0c45, 0x7402}: true, // TEMPerHUM
+}
+
+// IsSupportedDevice reports whether dev looks like a TEMPer sensor, going by
+// its model name or its USB vendor and product IDs, so that stray HID
+// entries can be skipped before opening.
+func IsSupportedDevice(dev TemperedDevice) bool {
+	if _, ok := knownModels[dev.TypeName]; ok {
+		return true
+	}
+	return knownIDs[quirkKey{dev.VendorId, dev.ProductId}]
+}
@@ -0,0 +1,100 @@
+package temperedgo
+
+import (
+	"context"
+	"sync"
+)
+
+// nativeCalls tracks the native calls running in the background on each
+// handle, so that the handle isn't closed, nor the library exited, under
+// one. Entries are removed as their last call finishes, so that the map
+// doesn't keep dropped handles from being finalized.
+var (
+	nativeCallsLock sync.Mutex
+	nativeCalls     = map[DeviceHandle]*nativeCall{}
+)
+
+type nativeCall struct {
+	wg sync.WaitGroup
+	n  int
+}
+
+// runNative calls f, which must only use dev, returning ctx.Err() if ctx is
+// done first. f then carries on in the background, and waitNative(dev)
+// waits for it. A ctx that can never be done calls f directly. The caller
+// must hold libLock.
+func runNative[T any](ctx context.Context, dev DeviceHandle, f func() T) (T, error) {
+	if ctx.Done() == nil {
+		return f(), nil
+	}
+
+	nativeCallsLock.Lock()
+	nc, ok := nativeCalls[dev]
+	if !ok {
+		nc = new(nativeCall)
+		nativeCalls[dev] = nc
+	}
+	nc.n++
+	nc.wg.Add(1)
+	nativeCallsLock.Unlock()
+
+	done := make(chan T, 1)
+	go func() {
+		defer func() {
+			nativeCallsLock.Lock()
+			nc.n--
+			if nc.n == 0 && nativeCalls[dev] == nc {
+				delete(nativeCalls, dev)
+			}
+			nativeCallsLock.Unlock()
+			nc.wg.Done()
+		}()
+		done <- f()
+	}()
+
+	select {
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	case v := <-done:
+		return v, nil
+	}
+}
+
+// runNative2 is runNative for the value-and-ok native getters.
+func runNative2(ctx context.Context, dev DeviceHandle, f func() (float64, bool)) (float64, bool, error) {
+	type result struct {
+		val float64
+		ok  bool
+	}
+	res, err := runNative(ctx, dev, func() result {
+		val, ok := f()
+		return result{val, ok}
+	})
+	return res.val, res.ok, err
+}
+
+// waitNative waits for background calls on dev to finish.
+func waitNative(dev DeviceHandle) {
+	nativeCallsLock.Lock()
+	nc := nativeCalls[dev]
+	nativeCallsLock.Unlock()
+
+	if nc != nil {
+		nc.wg.Wait()
+	}
+}
+
+// waitAllNative waits for background calls on every handle.
+func waitAllNative() {
+	nativeCallsLock.Lock()
+	ncs := make([]*nativeCall, 0, len(nativeCalls))
+	for _, nc := range nativeCalls {
+		ncs = append(ncs, nc)
+	}
+	nativeCallsLock.Unlock()
+
+	for _, nc := range ncs {
+		nc.wg.Wait()
+	}
+}
@@ -0,0 +1,17 @@
+package temperedgo
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// WriteNDJSON writes each reading to w as a JSON object on its own line.
+func WriteNDJSON(w io.Writer, readings []Reading) error {
+	enc := json.NewEncoder(w)
+	for _, r := range readings {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
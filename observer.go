@@ -0,0 +1,75 @@
+package temperedgo
+
+import (
+	"sync"
+	"time"
+)
+
+// Native operations reported to an Observer.
+const (
+	OP_UPDATE      = "update"
+	OP_TEMPERATURE = "temperature"
+	OP_HUMIDITY    = "humidity"
+	OP_READ_ALL    = "read_all"
+)
+
+// OperationEvent describes one native call on a device. SensorNum is -1 for
+// device-wide operations, and Value is only meaningful for successful
+// temperature and humidity reads.
+type OperationEvent struct {
+	DeviceID  string
+	Op        string
+	SensorNum int
+	Duration  time.Duration
+	Value     float64
+	Err       error
+}
+
+// Observer is told about every native device operation, for metrics and
+// tracing. It is called synchronously, so it should be quick.
+type Observer interface {
+	ObserveOperation(ev OperationEvent)
+}
+
+var (
+	observerLock sync.RWMutex
+	observer     Observer
+)
+
+// SetObserver installs o to receive every device operation, replacing any
+// previous observer. Passing nil removes it.
+func SetObserver(o Observer) {
+	observerLock.Lock()
+	defer observerLock.Unlock()
+
+	observer = o
+}
+
+func (t *TemperedDevice) observe(op string, sensorNum int, start time.Time, value float64, err error) {
+	d := time.Since(start)
+	t.latency = d
+
+	observerLock.RLock()
+	o := observer
+	observerLock.RUnlock()
+	if o == nil {
+		return
+	}
+	o.ObserveOperation(OperationEvent{
+		DeviceID:  t.ID(),
+		Op:        op,
+		SensorNum: sensorNum,
+		Duration:  d,
+		Value:     value,
+		Err:       err,
+	})
+}
+
+// LastReadLatency returns the wall-clock duration of the most recent native
+// update or read on the device. A steadily rising latency often precedes a
+// failing USB connection.
+func (t *TemperedDevice) LastReadLatency() time.Duration {
+	t.mutex().Lock()
+	defer t.mutex().Unlock()
+	return t.latency
+}
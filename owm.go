@@ -0,0 +1,49 @@
+package temperedgo
+
+import (
+	"math"
+)
+
+// OWMMain mirrors the "main" block of an OpenWeatherMap current weather
+// response (in metric units), so indoor readings can go through the same
+// code as weather API data. Humidity is zero when the reading has none.
+type OWMMain struct {
+	Temp      float64 `json:"temp"`
+	FeelsLike float64 `json:"feels_like"`
+	Humidity  float64 `json:"humidity"`
+}
+
+// ToOWM converts r, reporting false if it has no temperature. FeelsLike is
+// the heat index when r has a humidity, and the plain temperature
+// otherwise.
+func ToOWM(r Reading) (OWMMain, bool) {
+	if r.Temperature == nil {
+		return OWMMain{}, false
+	}
+	m := OWMMain{Temp: *r.Temperature, FeelsLike: *r.Temperature}
+	if r.Humidity != nil {
+		m.Humidity = *r.Humidity
+		m.FeelsLike = HeatIndex(m.Temp, m.Humidity)
+	}
+	return m, true
+}
+
+// HeatIndex is the US National Weather Service heat index, in degrees
+// Celsius, for a temperature in degrees Celsius and a relative humidity in
+// percent. In cool conditions, where the full regression doesn't apply, it
+// stays close to the air temperature.
+func HeatIndex(tempC, rh float64) float64 {
+	t := tempC*9/5 + 32
+	hi := 0.5 * (t + 61 + (t-68)*1.2 + rh*0.094)
+	if (hi+t)/2 >= 80 {
+		hi = -42.379 + 2.04901523*t + 10.14333127*rh -
+			0.22475541*t*rh - 0.00683783*t*t - 0.05481717*rh*rh +
+			0.00122874*t*t*rh + 0.00085282*t*rh*rh - 0.00000199*t*t*rh*rh
+		if rh < 13 && t >= 80 && t <= 112 {
+			hi -= (13 - rh) / 4 * math.Sqrt((17-math.Abs(t-95))/17)
+		} else if rh > 85 && t >= 80 && t <= 87 {
+			hi += (rh - 85) / 10 * (87 - t) / 5
+		}
+	}
+	return (hi - 32) * 5 / 9
+}
@@ -0,0 +1,35 @@
+package temperedgo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NormalizePath maps a device path to a form that stays the same for the
+// same physical port where the platform allows it, falling back to the path
+// itself:
+//
+//   - Linux hidraw nodes, whose numbers change as devices come and go,
+//     become "usb:<port path>:<config>.<interface>" from sysfs, e.g.
+//     "usb:1-1.2:1.0". This is stable while the device stays in the same
+//     USB port.
+//   - Windows device interface paths are case-insensitive, so are
+//     lowercased.
+//   - libusb-style "bus:address:interface" paths have no stable part (the
+//     address changes on replug) and are returned unchanged, as are macOS
+//     IOService paths, which already identify the port.
+func NormalizePath(path string) string {
+	if iface, ok := hidrawUSBInterface(path); ok {
+		return "usb:" + iface
+	}
+	if strings.HasPrefix(path, `\\?\`) {
+		return strings.ToLower(path)
+	}
+	return path
+}
+
+// StableID identifies the device by its vendor and product IDs and
+// normalised path, so that unlike ID it survives hidraw renumbering.
+func (t *TemperedDevice) StableID() string {
+	return fmt.Sprintf("%04x:%04x:%s", t.VendorId, t.ProductId, NormalizePath(t.Path))
+}
@@ -0,0 +1,130 @@
+package temperedgo
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// PollResult is one sample from a poll. Err is set, and Readings nil, if
+// the read failed.
+type PollResult struct {
+	Time     time.Time
+	Readings []Reading
+	Err      error
+
+	// Dropped is the number of ticks skipped so far in this poll because a
+	// read overran its interval.
+	Dropped int
+}
+
+// Poll reads the device straight away and then every interval until ctx is
+// done, then closes the channel. A failed read is sent as a PollResult with
+// Err set and polling carries on, except that once the device has been
+// closed, the ERR_NOT_OPEN result is the last one sent.
+func (t *TemperedDevice) Poll(ctx context.Context, interval time.Duration) (<-chan PollResult, error) {
+	if interval <= 0 {
+		return nil, errors.New("tempered: poll interval must be positive")
+	}
+
+	ch := make(chan PollResult)
+	go func() {
+		defer close(ch)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			tick := time.Now()
+			rs, err := t.ReadAll()
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- PollResult{Time: tick, Readings: rs, Err: err}:
+			}
+			if errors.Is(err, ERR_NOT_OPEN) {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// PollAligned reads the device on every multiple of interval (measured from
+// the zero time, so that an interval of a minute samples on the minute)
+// until ctx is done, then closes the channel. Samples stay on those
+// boundaries however long each read takes; a read that overruns the
+// interval causes the missed ticks to be skipped rather than read late, and
+// they are counted in PollResult.Dropped.
+func (t *TemperedDevice) PollAligned(ctx context.Context, interval time.Duration) (<-chan PollResult, error) {
+	if interval <= 0 {
+		return nil, errors.New("tempered: poll interval must be positive")
+	}
+
+	ch := make(chan PollResult)
+	go func() {
+		defer close(ch)
+
+		now := time.Now()
+		first := time.NewTimer(now.Truncate(interval).Add(interval).Sub(now))
+		defer first.Stop()
+		var tick time.Time
+		select {
+		case <-ctx.Done():
+			return
+		case tick = <-first.C:
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		dropped := 0
+		for {
+			rs, err := t.ReadAll()
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- PollResult{Time: tick, Readings: rs, Err: err, Dropped: dropped}:
+			}
+
+			if overrun := time.Since(tick); overrun >= interval {
+				dropped += int(overrun / interval)
+				// Discard the tick that fell due during the overrun, so the
+				// next read waits for a boundary.
+				select {
+				case <-ticker.C:
+				default:
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case tick = <-ticker.C:
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// PollReadings flattens a poll's results into their readings, for consumers
+// such as NewBroadcaster that take a channel of readings. Failed reads are
+// skipped. The returned channel is closed when src is.
+func PollReadings(src <-chan PollResult) <-chan Reading {
+	ch := make(chan Reading)
+	go func() {
+		defer close(ch)
+		for res := range src {
+			for _, r := range res.Readings {
+				ch <- r
+			}
+		}
+	}()
+	return ch
+}
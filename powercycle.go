@@ -0,0 +1,73 @@
+package temperedgo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const DEFAULT_POWER_CYCLE_TIMEOUT = 5 * time.Second
+
+// PowerCycle closes the device, deauthorises and reauthorises its USB
+// device through sysfs, which makes the kernel disconnect and re-enumerate
+// it, and then reopens it, for recovering a device that Reset cannot.
+//
+// It is best-effort and Linux-only: it needs a hidraw device path, and write
+// access to /sys/bus/usb/devices/<port>/authorized, which normally means
+// root or a udev rule granting it. Other paths fail with ERR_NOT_SUPPORTED.
+// The device may come back on a different hidraw node, in which case Path
+// is updated; if it doesn't reappear within DEFAULT_POWER_CYCLE_TIMEOUT the
+// device is left closed and ERR_NO_DEVICE_FOUND is returned.
+func (t *TemperedDevice) PowerCycle() error {
+	iface, ok := hidrawUSBInterface(t.Path)
+	if !ok {
+		return t.deviceError(ERR_NOT_SUPPORTED)
+	}
+	usbDev := iface[:strings.IndexByte(iface, ':')]
+	authorized := filepath.Join("/sys/bus/usb/devices", usbDev, "authorized")
+	stableID := t.StableID()
+
+	if err := t.Close(); err != nil {
+		return err
+	}
+	if err := os.WriteFile(authorized, []byte("0"), 0); err != nil {
+		return t.deviceError(fmt.Errorf("deauthorising %s: %w", usbDev, err))
+	}
+	if err := os.WriteFile(authorized, []byte("1"), 0); err != nil {
+		return t.deviceError(fmt.Errorf("reauthorising %s: %w", usbDev, err))
+	}
+
+	deadline := time.Now().Add(DEFAULT_POWER_CYCLE_TIMEOUT)
+	for {
+		if path, ok := t.findStable(stableID); ok {
+			t.Path = path
+			return t.Open()
+		}
+		if time.Now().After(deadline) {
+			return t.deviceError(ERR_NO_DEVICE_FOUND)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// findStable enumerates devices looking for one with the given StableID,
+// returning its path.
+func (t *TemperedDevice) findStable(stableID string) (string, bool) {
+	if err := acquireLib(t.backendOrDefault()); err != nil {
+		return "", false
+	}
+	defer libLock.RUnlock()
+
+	tds, err := t.backendOrDefault().Enumerate()
+	if err != nil {
+		return "", false
+	}
+	for _, td := range tds {
+		if td.StableID() == stableID {
+			return td.Path, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,156 @@
+// Package prometheus exposes device readings as a Prometheus collector.
+package prometheus
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	temperedgo "github.com/lukegb/tempered-go"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+const DEFAULT_RESCAN_INTERVAL = time.Minute
+
+var (
+	labels = []string{"path", "type_name", "sensor"}
+
+	temperatureDesc = prom.NewDesc("tempered_temperature_celsius", "Sensor temperature in degrees Celsius.", labels, nil)
+	humidityDesc    = prom.NewDesc("tempered_humidity_percent", "Sensor relative humidity in percent.", labels, nil)
+	readErrorsDesc  = prom.NewDesc("tempered_read_errors_total", "Failed device enumerations, opens and reads. Enumeration failures have an empty path.", []string{"path"}, nil)
+)
+
+// Collector reads every device on each scrape. Devices are kept open between
+// scrapes, and the device list is only re-enumerated every RescanInterval,
+// or sooner if a device has failed, so a steady scrape costs one read per
+// device. A device that fails to open or read is counted in
+// tempered_read_errors_total and closed, to be reopened once it's found
+// again.
+type Collector struct {
+	Tempered *temperedgo.Tempered
+
+	// RescanInterval is how often the device list is re-enumerated to find
+	// new devices. Zero means DEFAULT_RESCAN_INTERVAL.
+	RescanInterval time.Duration
+
+	lock       sync.Mutex
+	devices    map[string]*temperedgo.TemperedDevice
+	lastScan   time.Time
+	rescan     bool
+	readErrors map[string]float64
+}
+
+// New returns a Collector for the devices on t, which must already be
+// initialised.
+func New(t *temperedgo.Tempered) *Collector {
+	return &Collector{
+		Tempered:   t,
+		devices:    make(map[string]*temperedgo.TemperedDevice),
+		readErrors: make(map[string]float64),
+	}
+}
+
+func (c *Collector) Describe(ch chan<- *prom.Desc) {
+	ch <- temperatureDesc
+	ch <- humidityDesc
+	ch <- readErrorsDesc
+}
+
+func (c *Collector) Collect(ch chan<- prom.Metric) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.refresh()
+
+	ids := make([]string, 0, len(c.devices))
+	for id := range c.devices {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		dev := c.devices[id]
+		rs, err := dev.ReadAll()
+		if err != nil {
+			c.fail(id, dev)
+			continue
+		}
+		for _, r := range rs {
+			values := []string{dev.Path, dev.TypeName, strconv.Itoa(r.SensorNum)}
+			if r.Temperature != nil {
+				ch <- prom.MustNewConstMetric(temperatureDesc, prom.GaugeValue, *r.Temperature, values...)
+			}
+			if r.Humidity != nil {
+				ch <- prom.MustNewConstMetric(humidityDesc, prom.GaugeValue, *r.Humidity, values...)
+			}
+		}
+	}
+
+	for path, n := range c.readErrors {
+		ch <- prom.MustNewConstMetric(readErrorsDesc, prom.CounterValue, n, path)
+	}
+}
+
+// refresh re-enumerates if it's due, opening devices not already open and
+// closing those that have gone. The caller must hold lock.
+func (c *Collector) refresh() {
+	interval := c.RescanInterval
+	if interval == 0 {
+		interval = DEFAULT_RESCAN_INTERVAL
+	}
+	if !c.rescan && !c.lastScan.IsZero() && time.Since(c.lastScan) < interval {
+		return
+	}
+
+	tds, err := c.Tempered.DeviceList()
+	if err != nil {
+		c.readErrors[""]++
+		return
+	}
+	c.lastScan = time.Now()
+	c.rescan = false
+
+	present := make(map[string]bool, len(tds))
+	for _, td := range tds {
+		id := td.ID()
+		present[id] = true
+		if _, ok := c.devices[id]; ok {
+			continue
+		}
+		dev := td
+		if err := dev.Open(); err != nil {
+			c.readErrors[dev.Path]++
+			continue
+		}
+		c.devices[id] = &dev
+	}
+	for id, dev := range c.devices {
+		if !present[id] {
+			dev.Close()
+			delete(c.devices, id)
+		}
+	}
+}
+
+// fail counts a read error on a device and closes it, so that the next
+// scrape re-enumerates. The caller must hold lock.
+func (c *Collector) fail(id string, dev *temperedgo.TemperedDevice) {
+	c.readErrors[dev.Path]++
+	dev.Close()
+	delete(c.devices, id)
+	c.rescan = true
+}
+
+// Close closes every device the Collector has open. It does not Exit the
+// Tempered.
+func (c *Collector) Close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for id, dev := range c.devices {
+		dev.Close()
+		delete(c.devices, id)
+	}
+	return nil
+}
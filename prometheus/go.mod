@@ -0,0 +1,10 @@
+module github.com/lukegb/tempered-go/prometheus
+
+go 1.21
+
+require (
+	github.com/lukegb/tempered-go v0.0.0
+	github.com/prometheus/client_golang v1.19.0
+)
+
+replace github.com/lukegb/tempered-go => ../
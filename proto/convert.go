@@ -0,0 +1,53 @@
+// Package proto converts readings to and from their protobuf representation.
+package proto
+
+//go:generate protoc --go_out=temperedpb --go_opt=paths=source_relative -I temperedpb temperedpb/reading.proto
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	temperedgo "github.com/lukegb/tempered-go"
+	pb "github.com/lukegb/tempered-go/proto/temperedpb"
+)
+
+func ToProto(r temperedgo.Reading) *pb.Reading {
+	p := &pb.Reading{
+		Time:      timestamppb.New(r.Time),
+		DeviceId:  r.DeviceID,
+		SensorNum: int32(r.SensorNum),
+		Label:     r.Label,
+	}
+	if r.Temperature != nil {
+		p.Temperature = *r.Temperature
+		p.HasTemperature = true
+	}
+	if r.Humidity != nil {
+		p.Humidity = *r.Humidity
+		p.HasHumidity = true
+	}
+	return p
+}
+
+// FromProto is the inverse of ToProto. The sensor type mask is rebuilt from
+// the presence flags.
+func FromProto(p *pb.Reading) temperedgo.Reading {
+	r := temperedgo.Reading{
+		DeviceID:  p.GetDeviceId(),
+		SensorNum: int(p.GetSensorNum()),
+		Label:     p.GetLabel(),
+	}
+	if p.GetTime() != nil {
+		r.Time = p.GetTime().AsTime()
+	}
+	if p.GetHasTemperature() {
+		val := p.GetTemperature()
+		r.Temperature = &val
+		r.Type |= temperedgo.TEMPERED_SENSOR_TYPE_TEMPERATURE
+	}
+	if p.GetHasHumidity() {
+		val := p.GetHumidity()
+		r.Humidity = &val
+		r.Type |= temperedgo.TEMPERED_SENSOR_TYPE_HUMIDITY
+	}
+	return r
+}
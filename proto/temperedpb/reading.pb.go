@@ -0,0 +1,189 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        v5.28.3
+// source: reading.proto
+
+package temperedpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Reading struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Time           *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	DeviceId       string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	SensorNum      int32                  `protobuf:"varint,3,opt,name=sensor_num,json=sensorNum,proto3" json:"sensor_num,omitempty"`
+	Label          string                 `protobuf:"bytes,4,opt,name=label,proto3" json:"label,omitempty"`
+	Temperature    float64                `protobuf:"fixed64,5,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	HasTemperature bool                   `protobuf:"varint,6,opt,name=has_temperature,json=hasTemperature,proto3" json:"has_temperature,omitempty"`
+	Humidity       float64                `protobuf:"fixed64,7,opt,name=humidity,proto3" json:"humidity,omitempty"`
+	HasHumidity    bool                   `protobuf:"varint,8,opt,name=has_humidity,json=hasHumidity,proto3" json:"has_humidity,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Reading) Reset() {
+	*x = Reading{}
+	mi := &file_reading_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reading) ProtoMessage() {}
+
+func (x *Reading) ProtoReflect() protoreflect.Message {
+	mi := &file_reading_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reading.ProtoReflect.Descriptor instead.
+func (*Reading) Descriptor() ([]byte, []int) {
+	return file_reading_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Reading) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Reading) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *Reading) GetSensorNum() int32 {
+	if x != nil {
+		return x.SensorNum
+	}
+	return 0
+}
+
+func (x *Reading) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *Reading) GetTemperature() float64 {
+	if x != nil {
+		return x.Temperature
+	}
+	return 0
+}
+
+func (x *Reading) GetHasTemperature() bool {
+	if x != nil {
+		return x.HasTemperature
+	}
+	return false
+}
+
+func (x *Reading) GetHumidity() float64 {
+	if x != nil {
+		return x.Humidity
+	}
+	return 0
+}
+
+func (x *Reading) GetHasHumidity() bool {
+	if x != nil {
+		return x.HasHumidity
+	}
+	return false
+}
+
+var File_reading_proto protoreflect.FileDescriptor
+
+const file_reading_proto_rawDesc = "" +
+	"\n" +
+	"\rreading.proto\x12\btempered\x1a\x1fgoogle/protobuf/timestamp.proto\"\x95\x02\n" +
+	"\aReading\x12.\n" +
+	"\x04time\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12\x1d\n" +
+	"\n" +
+	"sensor_num\x18\x03 \x01(\x05R\tsensorNum\x12\x14\n" +
+	"\x05label\x18\x04 \x01(\tR\x05label\x12 \n" +
+	"\vtemperature\x18\x05 \x01(\x01R\vtemperature\x12'\n" +
+	"\x0fhas_temperature\x18\x06 \x01(\bR\x0ehasTemperature\x12\x1a\n" +
+	"\bhumidity\x18\a \x01(\x01R\bhumidity\x12!\n" +
+	"\fhas_humidity\x18\b \x01(\bR\vhasHumidityB0Z.github.com/lukegb/tempered-go/proto/temperedpbb\x06proto3"
+
+var (
+	file_reading_proto_rawDescOnce sync.Once
+	file_reading_proto_rawDescData []byte
+)
+
+func file_reading_proto_rawDescGZIP() []byte {
+	file_reading_proto_rawDescOnce.Do(func() {
+		file_reading_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_reading_proto_rawDesc), len(file_reading_proto_rawDesc)))
+	})
+	return file_reading_proto_rawDescData
+}
+
+var file_reading_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_reading_proto_goTypes = []any{
+	(*Reading)(nil),               // 0: tempered.Reading
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_reading_proto_depIdxs = []int32{
+	1, // 0: tempered.Reading.time:type_name -> google.protobuf.Timestamp
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_reading_proto_init() }
+func file_reading_proto_init() {
+	if File_reading_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_reading_proto_rawDesc), len(file_reading_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_reading_proto_goTypes,
+		DependencyIndexes: file_reading_proto_depIdxs,
+		MessageInfos:      file_reading_proto_msgTypes,
+	}.Build()
+	File_reading_proto = out.File
+	file_reading_proto_goTypes = nil
+	file_reading_proto_depIdxs = nil
+}
@@ -0,0 +1,153 @@
+package temperedgo
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Quirk corrects a model's raw values before calibration offsets are
+// applied. Either function may be nil to leave that measurement alone.
+// ReversedHumidityQuirk and TemperatureOffsetQuirk cover the common clone
+// faults, but none is registered by default, as no fault is known to hold
+// for every device sharing a vendor and product ID; register the ones your
+// hardware needs with RegisterQuirk.
+type Quirk struct {
+	Name        string
+	Temperature func(tempC float64) float64
+	Humidity    func(rh float64) float64
+}
+
+// ReversedHumidityQuirk corrects clones whose humidity scale runs backwards,
+// reading 100%RH minus the true value.
+func ReversedHumidityQuirk() Quirk {
+	return Quirk{
+		Name:     "reversed humidity",
+		Humidity: func(rh float64) float64 { return 100 - rh },
+	}
+}
+
+// TemperatureOffsetQuirk corrects clones whose firmware adds offset degrees
+// Celsius to every temperature it reports.
+func TemperatureOffsetQuirk(offset float64) Quirk {
+	return Quirk{
+		Name:        fmt.Sprintf("temperature offset %+g°C", offset),
+		Temperature: func(tempC float64) float64 { return tempC - offset },
+	}
+}
+
+type quirkKey struct {
+	vendorId, productId uint
+}
+
+var (
+	quirksLock sync.RWMutex
+	quirks     = map[quirkKey]Quirk{}
+)
+
+func clampHumidity(rh float64) float64 {
+	if rh < 0 {
+		return 0
+	}
+	if rh > 100 {
+		return 100
+	}
+	return rh
+}
+
+// RegisterQuirk sets the quirk applied to devices with the given IDs,
+// replacing any already registered.
+func RegisterQuirk(vendorId, productId uint, q Quirk) {
+	quirksLock.Lock()
+	defer quirksLock.Unlock()
+
+	quirks[quirkKey{vendorId, productId}] = q
+}
+
+// QuirkFor returns the quirk registered for the given IDs.
+func QuirkFor(vendorId, productId uint) (Quirk, bool) {
+	quirksLock.RLock()
+	defer quirksLock.RUnlock()
+
+	q, ok := quirks[quirkKey{vendorId, productId}]
+	return q, ok
+}
+
+// CorrectionWarning reports a value that was plausible as the device read it
+// but not after quirks and calibration were applied, which usually means a
+// misconfigured calibration offset.
+type CorrectionWarning struct {
+	DeviceID  string
+	SensorNum int
+	Op        string // OP_TEMPERATURE or OP_HUMIDITY
+	Raw       float64
+	Corrected float64
+}
+
+func (w CorrectionWarning) Error() string {
+	return fmt.Sprintf("tempered: %s sensor %d %s corrected from %g to %g: %v", w.DeviceID, w.SensorNum, w.Op, w.Raw, w.Corrected, ERR_IMPLAUSIBLE)
+}
+
+func (w CorrectionWarning) Unwrap() error {
+	return ERR_IMPLAUSIBLE
+}
+
+var (
+	correctionWarningLock sync.RWMutex
+	correctionWarning     func(CorrectionWarning)
+)
+
+// SetCorrectionWarningHandler installs f to be called, synchronously, whenever
+// correcting a value pushes it out of the plausible range. The corrected value
+// is still returned to the caller; use PlausibleValidator to reject it. Passing
+// nil removes the handler.
+func SetCorrectionWarningHandler(f func(CorrectionWarning)) {
+	correctionWarningLock.Lock()
+	defer correctionWarningLock.Unlock()
+
+	correctionWarning = f
+}
+
+func (t *TemperedDevice) checkCorrection(sensorNum int, op string, raw, corrected, min, max float64) {
+	if raw < min || raw > max || (corrected >= min && corrected <= max) {
+		return
+	}
+
+	correctionWarningLock.RLock()
+	f := correctionWarning
+	correctionWarningLock.RUnlock()
+	if f == nil {
+		return
+	}
+	f(CorrectionWarning{
+		DeviceID:  t.ID(),
+		SensorNum: sensorNum,
+		Op:        op,
+		Raw:       raw,
+		Corrected: corrected,
+	})
+}
+
+// correctTemperature applies the device's quirk and then the calibration
+// cal. correctHumidity also applies ClampHumidity, last.
+func (t *TemperedDevice) correctTemperature(sensorNum int, tempC float64, cal calibration) float64 {
+	raw := tempC
+	if q, ok := QuirkFor(t.VendorId, t.ProductId); ok && q.Temperature != nil {
+		tempC = q.Temperature(tempC)
+	}
+	tempC += cal.Temperature
+	t.checkCorrection(sensorNum, OP_TEMPERATURE, raw, tempC, plausibleMinTemperature, plausibleMaxTemperature)
+	return tempC
+}
+
+func (t *TemperedDevice) correctHumidity(sensorNum int, rh float64, cal calibration) float64 {
+	raw := rh
+	if q, ok := QuirkFor(t.VendorId, t.ProductId); ok && q.Humidity != nil {
+		rh = q.Humidity(rh)
+	}
+	rh += cal.Humidity
+	if t.ClampHumidity {
+		rh = clampHumidity(rh)
+	}
+	t.checkCorrection(sensorNum, OP_HUMIDITY, raw, rh, plausibleMinHumidity, plausibleMaxHumidity)
+	return rh
+}
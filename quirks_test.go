@@ -0,0 +1,69 @@
+package temperedgo
+
+import (
+	"testing"
+)
+
+func TestHumidityUnclampedByDefault(t *testing.T) {
+	fb := &FakeBackend{Devices: []*FakeDevice{{
+		Path:      "/dev/fake0",
+		TypeName:  "TEMPerHUM",
+		VendorId:  0x0c45,
+		ProductId: 0x7402,
+		Sensors:   []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_ALL, Temperature: 5, Humidity: 102.5}},
+	}}}
+	td := openFake(t, fb)
+	if err := td.Update(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := td.Humidity(0); err != nil || got != 102.5 {
+		t.Errorf("Humidity(0) = %v, %v; want the unclamped 102.5, nil", got, err)
+	}
+	td.ClampHumidity = true
+	if got, err := td.Humidity(0); err != nil || got != 100 {
+		t.Errorf("Humidity(0) with ClampHumidity = %v, %v; want 100, nil", got, err)
+	}
+	if got, err := td.RawHumidity(0); err != nil || got != 102.5 {
+		t.Errorf("RawHumidity(0) with ClampHumidity = %v, %v; want 102.5, nil", got, err)
+	}
+}
+
+func TestBuiltInQuirks(t *testing.T) {
+	// IDs no real device uses, as quirks can't be unregistered.
+	RegisterQuirk(0xfffe, 0x0001, ReversedHumidityQuirk())
+	RegisterQuirk(0xfffe, 0x0002, TemperatureOffsetQuirk(1.5))
+	if _, ok := QuirkFor(0xfffe, 0x0003); ok {
+		t.Error("QuirkFor found a quirk for unregistered IDs")
+	}
+
+	fb := &FakeBackend{Devices: []*FakeDevice{
+		{Path: "/dev/fake0", VendorId: 0xfffe, ProductId: 0x0001,
+			Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_ALL, Temperature: 20, Humidity: 70}}},
+	}}
+	td := openFake(t, fb)
+	if err := td.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := td.Humidity(0); err != nil || got != 30 {
+		t.Errorf("Humidity(0) with a reversed scale = %v, %v; want 30, nil", got, err)
+	}
+	if got, err := td.Temperature(0); err != nil || got != 20 {
+		t.Errorf("Temperature(0) with a reversed humidity scale = %v, %v; want 20, nil", got, err)
+	}
+
+	fb = &FakeBackend{Devices: []*FakeDevice{
+		{Path: "/dev/fake0", VendorId: 0xfffe, ProductId: 0x0002,
+			Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_ALL, Temperature: 21.5, Humidity: 70}}},
+	}}
+	td = openFake(t, fb)
+	if err := td.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := td.Temperature(0); err != nil || got != 20 {
+		t.Errorf("Temperature(0) with a +1.5°C offset = %v, %v; want 20, nil", got, err)
+	}
+	if got, err := td.Humidity(0); err != nil || got != 70 {
+		t.Errorf("Humidity(0) with a temperature offset = %v, %v; want 70, nil", got, err)
+	}
+}
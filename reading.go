@@ -0,0 +1,281 @@
+package temperedgo
+
+import (
+	"maps"
+	"math"
+	"sort"
+	"time"
+)
+
+// Reading is a snapshot of a single sensor. Temperature and Humidity are nil
+// when the sensor does not support that measurement. Humidity is always
+// relative humidity in percent; AbsoluteHumidity derives the absolute form.
+type Reading struct {
+	Time        time.Time          `json:"time"`
+	DeviceID    string             `json:"device_id"`
+	SensorNum   int                `json:"sensor_num"`
+	Type        TemperedSensorType `json:"type"`
+	Role        SensorRole         `json:"role"`
+	Label       string             `json:"label,omitempty"`
+	Temperature *float64           `json:"temperature,omitempty"`
+	Humidity    *float64           `json:"humidity,omitempty"`
+	Tags        map[string]string  `json:"tags,omitempty"`
+
+	// TemperatureUncertainty and HumidityUncertainty are the accuracy
+	// registered with SetAccuracy for the device's model, when there is one.
+	TemperatureUncertainty *float64 `json:"temperature_uncertainty,omitempty"`
+	HumidityUncertainty    *float64 `json:"humidity_uncertainty,omitempty"`
+}
+
+// cloneReadings deep-copies rs, so that the copy shares no pointers or maps
+// with the original.
+func cloneReadings(rs []Reading) []Reading {
+	clonePtr := func(v *float64) *float64 {
+		if v == nil {
+			return nil
+		}
+		c := *v
+		return &c
+	}
+
+	out := make([]Reading, len(rs))
+	for n, r := range rs {
+		r.Temperature = clonePtr(r.Temperature)
+		r.Humidity = clonePtr(r.Humidity)
+		r.TemperatureUncertainty = clonePtr(r.TemperatureUncertainty)
+		r.HumidityUncertainty = clonePtr(r.HumidityUncertainty)
+		r.Tags = maps.Clone(r.Tags)
+		out[n] = r
+	}
+	return out
+}
+
+// RelativeHumidity returns Humidity, the relative humidity in percent.
+func (r Reading) RelativeHumidity() (float64, bool) {
+	if r.Humidity == nil {
+		return 0, false
+	}
+	return *r.Humidity, true
+}
+
+// AbsoluteHumidity returns the water vapour density in g/m³, computed from
+// Temperature, which must be in degrees Celsius, and Humidity. It reports
+// false unless the reading has both.
+func (r Reading) AbsoluteHumidity() (float64, bool) {
+	if r.Temperature == nil || r.Humidity == nil {
+		return 0, false
+	}
+	return absoluteHumidity(*r.Temperature, *r.Humidity), true
+}
+
+// Read fetches every measurement the sensor advertises from the values cached
+// by the last Update.
+func (ts *TemperedSensor) Read() (Reading, error) {
+	r := Reading{
+		Time:      time.Now(),
+		DeviceID:  ts.device.ID(),
+		SensorNum: ts.sensorNum,
+		Type:      ts.TypeMask,
+		Role:      ts.Role,
+		Label:     ts.Label,
+		Tags:      maps.Clone(ts.Tags),
+	}
+	if ts.TypeMask.IsType(TEMPERED_SENSOR_TYPE_TEMPERATURE) {
+		tempC, err := ts.Temperature()
+		if err != nil {
+			return Reading{}, err
+		}
+		val := tempC.Float64()
+		r.Temperature = &val
+	}
+	if ts.TypeMask.IsType(TEMPERED_SENSOR_TYPE_HUMIDITY) {
+		val, err := ts.Humidity()
+		if err != nil {
+			return Reading{}, err
+		}
+		r.Humidity = &val
+	}
+	return r, nil
+}
+
+// sortedTags returns the keys of tags in sorted order, so exporters emit
+// them deterministically.
+func sortedTags(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ValueReading is Reading with plain value fields. Valid has a sensor type
+// bit set for each field that holds a measurement; the others are zero.
+type ValueReading struct {
+	Time        time.Time
+	DeviceID    string
+	SensorNum   int
+	Role        SensorRole
+	Label       string
+	Tags        map[string]string
+	Valid       TemperedSensorType
+	Temperature float64
+	Humidity    float64
+}
+
+func (vr ValueReading) HasTemperature() bool {
+	return vr.Valid.IsType(TEMPERED_SENSOR_TYPE_TEMPERATURE)
+}
+
+func (vr ValueReading) HasHumidity() bool {
+	return vr.Valid.IsType(TEMPERED_SENSOR_TYPE_HUMIDITY)
+}
+
+func (r Reading) Values() ValueReading {
+	vr := ValueReading{
+		Time:      r.Time,
+		DeviceID:  r.DeviceID,
+		SensorNum: r.SensorNum,
+		Role:      r.Role,
+		Label:     r.Label,
+		Tags:      r.Tags,
+	}
+	if r.Temperature != nil {
+		vr.Valid |= TEMPERED_SENSOR_TYPE_TEMPERATURE
+		vr.Temperature = *r.Temperature
+	}
+	if r.Humidity != nil {
+		vr.Valid |= TEMPERED_SENSOR_TYPE_HUMIDITY
+		vr.Humidity = *r.Humidity
+	}
+	return vr
+}
+
+// Reading converts back to the pointer representation, with Type set to the
+// valid mask.
+func (vr ValueReading) Reading() Reading {
+	r := Reading{
+		Time:      vr.Time,
+		DeviceID:  vr.DeviceID,
+		SensorNum: vr.SensorNum,
+		Role:      vr.Role,
+		Label:     vr.Label,
+		Tags:      vr.Tags,
+		Type:      vr.Valid,
+	}
+	if vr.HasTemperature() {
+		val := vr.Temperature
+		r.Temperature = &val
+	}
+	if vr.HasHumidity() {
+		val := vr.Humidity
+		r.Humidity = &val
+	}
+	return r
+}
+
+type DetailedValue struct {
+	Value float64
+	Err   error
+}
+
+// DetailedReading records each measurement's outcome separately, so a failed
+// humidity read doesn't hide a good temperature and vice versa.
+// Measurements the sensor doesn't support carry ERR_UNSUPPORTED_MEASUREMENT.
+type DetailedReading struct {
+	Time        time.Time
+	DeviceID    string
+	SensorNum   int
+	Label       string
+	Temperature DetailedValue
+	Humidity    DetailedValue
+}
+
+func (ts *TemperedSensor) ReadDetailed() DetailedReading {
+	dr := DetailedReading{
+		Time:      time.Now(),
+		DeviceID:  ts.device.ID(),
+		SensorNum: ts.sensorNum,
+		Label:     ts.Label,
+	}
+	if ts.TypeMask.IsType(TEMPERED_SENSOR_TYPE_TEMPERATURE) {
+		var tempC Celsius
+		tempC, dr.Temperature.Err = ts.Temperature()
+		dr.Temperature.Value = tempC.Float64()
+	} else {
+		dr.Temperature.Err = ERR_UNSUPPORTED_MEASUREMENT
+	}
+	if ts.TypeMask.IsType(TEMPERED_SENSOR_TYPE_HUMIDITY) {
+		dr.Humidity.Value, dr.Humidity.Err = ts.Humidity()
+	} else {
+		dr.Humidity.Err = ERR_UNSUPPORTED_MEASUREMENT
+	}
+	return dr
+}
+
+// Temperatures updates the device once and returns the temperature of every
+// temperature-capable sensor, keyed by sensor number.
+func (t *TemperedDevice) Temperatures() (map[int]float64, error) {
+	rs, err := t.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	temps := map[int]float64{}
+	for _, r := range rs {
+		if r.Temperature != nil {
+			temps[r.SensorNum] = *r.Temperature
+		}
+	}
+	return temps, nil
+}
+
+// Humidities is Temperatures for relative humidity.
+func (t *TemperedDevice) Humidities() (map[int]float64, error) {
+	rs, err := t.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	hums := map[int]float64{}
+	for _, r := range rs {
+		if r.Humidity != nil {
+			hums[r.SensorNum] = *r.Humidity
+		}
+	}
+	return hums, nil
+}
+
+// ReadingsEqual reports whether a and b carry the same measurements: the same
+// ones must be present, and each must agree within its tolerance. Time and
+// the fields identifying the sensor are not compared.
+func ReadingsEqual(a, b Reading, tempTol, humidityTol float64) bool {
+	va, vb := a.Values(), b.Values()
+	if va.Valid != vb.Valid {
+		return false
+	}
+	if va.HasTemperature() && math.Abs(va.Temperature-vb.Temperature) > tempTol {
+		return false
+	}
+	if va.HasHumidity() && math.Abs(va.Humidity-vb.Humidity) > humidityTol {
+		return false
+	}
+	return true
+}
+
+// Temperature0 updates the device and returns the temperature of its first
+// temperature-capable sensor, failing with ERR_UNSUPPORTED_MEASUREMENT if it
+// has none. It covers the common single-sensor case in one call.
+func (t *TemperedDevice) Temperature0() (Celsius, error) {
+	if err := t.Update(); err != nil {
+		return 0, err
+	}
+	tsList, err := t.SortedSensors()
+	if err != nil {
+		return 0, err
+	}
+	for _, ts := range tsList {
+		if ts.TypeMask.IsType(TEMPERED_SENSOR_TYPE_TEMPERATURE) {
+			return ts.Temperature()
+		}
+	}
+	return 0, t.deviceError(ERR_UNSUPPORTED_MEASUREMENT)
+}
@@ -0,0 +1,121 @@
+package temperedgo
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+)
+
+// BenchmarkReadRate updates and reads every sensor as fast as possible for d,
+// or until ctx is done, and reports how many full reads succeeded and failed.
+func (t *TemperedDevice) BenchmarkReadRate(ctx context.Context, d time.Duration) (reads int, errs int) {
+	ctx, cancel := context.WithTimeout(ctx, d)
+	defer cancel()
+
+	for ctx.Err() == nil {
+		if _, err := t.ReadAll(); err != nil {
+			errs++
+		} else {
+			reads++
+		}
+	}
+	return reads, errs
+}
+
+const DEFAULT_BENCHMARK_ITERATIONS = 20
+
+// LatencyStats summarises a set of operation latencies.
+type LatencyStats struct {
+	Samples int
+	Min     time.Duration
+	P50     time.Duration
+	P90     time.Duration
+	P99     time.Duration
+	Max     time.Duration
+}
+
+func latencyStats(ds []time.Duration) LatencyStats {
+	if len(ds) == 0 {
+		return LatencyStats{}
+	}
+	sorted := append([]time.Duration(nil), ds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	at := func(p float64) time.Duration {
+		return sorted[int(math.Ceil(p*float64(len(sorted))))-1]
+	}
+	return LatencyStats{
+		Samples: len(sorted),
+		Min:     sorted[0],
+		P50:     at(0.5),
+		P90:     at(0.9),
+		P99:     at(0.99),
+		Max:     sorted[len(sorted)-1],
+	}
+}
+
+// BenchmarkResult breaks a benchmark run down by operation. Read is the
+// time to fetch every sensor's values from the cache an update filled.
+type BenchmarkResult struct {
+	Open   LatencyStats
+	Update LatencyStats
+	Read   LatencyStats
+}
+
+// Benchmark times iterations rounds of opening, updating, reading and
+// closing the device, or DEFAULT_BENCHMARK_ITERATIONS if iterations is not
+// positive, stopping early if ctx is done. The device is closed first and
+// left open afterwards. Any failure ends the run with its error.
+func (t *TemperedDevice) Benchmark(ctx context.Context, iterations int) (BenchmarkResult, error) {
+	if iterations <= 0 {
+		iterations = DEFAULT_BENCHMARK_ITERATIONS
+	}
+	if err := t.Close(); err != nil {
+		return BenchmarkResult{}, err
+	}
+
+	var opens, updates, reads []time.Duration
+	for n := 0; n < iterations && ctx.Err() == nil; n++ {
+		if n > 0 {
+			if err := t.Close(); err != nil {
+				return BenchmarkResult{}, err
+			}
+		}
+
+		start := time.Now()
+		if err := t.Open(); err != nil {
+			return BenchmarkResult{}, err
+		}
+		opens = append(opens, time.Since(start))
+
+		start = time.Now()
+		if err := t.Update(); err != nil {
+			return BenchmarkResult{}, err
+		}
+		updates = append(updates, time.Since(start))
+
+		start = time.Now()
+		if err := t.benchmarkRead(); err != nil {
+			return BenchmarkResult{}, err
+		}
+		reads = append(reads, time.Since(start))
+	}
+
+	return BenchmarkResult{
+		Open:   latencyStats(opens),
+		Update: latencyStats(updates),
+		Read:   latencyStats(reads),
+	}, nil
+}
+
+func (t *TemperedDevice) benchmarkRead() error {
+	if err := acquireLib(t.backendOrDefault()); err != nil {
+		return t.deviceError(err)
+	}
+	defer libLock.RUnlock()
+	t.lockDevice()
+	defer t.unlockDevice()
+
+	_, err := t.readCached(time.Now())
+	return err
+}
@@ -0,0 +1,108 @@
+package temperedgo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// DeviceReader is anything that can be read like a device: a
+// *TemperedDevice or a ReplaySource.
+type DeviceReader interface {
+	ReadAll() ([]Reading, error)
+}
+
+// ReplaySource plays back readings captured with WriteNDJSON. Consecutive
+// readings with the same Time, as ReadAll produces, are replayed together as
+// one read.
+type ReplaySource struct {
+	lock    sync.Mutex
+	batches [][]Reading
+	next    int
+}
+
+// NewReplaySource decodes every reading from r up front.
+func NewReplaySource(r io.Reader) (*ReplaySource, error) {
+	rs := &ReplaySource{}
+	dec := json.NewDecoder(r)
+	for {
+		var rd Reading
+		if err := dec.Decode(&rd); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if n := len(rs.batches); n > 0 && rs.batches[n-1][0].Time.Equal(rd.Time) {
+			rs.batches[n-1] = append(rs.batches[n-1], rd)
+		} else {
+			rs.batches = append(rs.batches, []Reading{rd})
+		}
+	}
+	return rs, nil
+}
+
+// ReadAll returns the next captured read, or io.EOF once they have all been
+// returned. The readings keep their original timestamps.
+func (rs *ReplaySource) ReadAll() ([]Reading, error) {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	if rs.next >= len(rs.batches) {
+		return nil, io.EOF
+	}
+	b := rs.batches[rs.next]
+	rs.next++
+	return append([]Reading(nil), b...), nil
+}
+
+// Rewind starts the replay again from the first read.
+func (rs *ReplaySource) Rewind() {
+	rs.lock.Lock()
+	defer rs.lock.Unlock()
+
+	rs.next = 0
+}
+
+// Poll sends the remaining captured reads on the returned channel, spaced
+// as they originally were divided by speed, so a speed of 2 replays twice
+// as fast. A speed of zero or less sends them without delay. The channel is
+// closed after the last read or when ctx is done. PollResult.Time is the
+// original time of each read.
+func (rs *ReplaySource) Poll(ctx context.Context, speed float64) <-chan PollResult {
+	ch := make(chan PollResult)
+	go func() {
+		defer close(ch)
+
+		var prev time.Time
+		for {
+			readings, err := rs.ReadAll()
+			if err != nil {
+				return
+			}
+			at := readings[0].Time
+			if speed > 0 && !prev.IsZero() {
+				if d := time.Duration(float64(at.Sub(prev)) / speed); d > 0 {
+					timer := time.NewTimer(d)
+					select {
+					case <-ctx.Done():
+						timer.Stop()
+						return
+					case <-timer.C:
+					}
+				}
+			}
+			prev = at
+
+			select {
+			case <-ctx.Done():
+				return
+			case ch <- PollResult{Time: at, Readings: readings}:
+			}
+		}
+	}()
+	return ch
+}
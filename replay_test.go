@@ -0,0 +1,71 @@
+package temperedgo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestReplaySource(t *testing.T) {
+	at := time.Unix(1700000000, 0).UTC()
+	temp := 20.0
+	var buf bytes.Buffer
+	err := WriteNDJSON(&buf, []Reading{
+		{Time: at, DeviceID: "/dev/a", SensorNum: 0, Temperature: &temp},
+		{Time: at, DeviceID: "/dev/a", SensorNum: 1, Temperature: &temp},
+		{Time: at.Add(time.Minute), DeviceID: "/dev/a", SensorNum: 0, Temperature: &temp},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rs, err := NewReplaySource(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var src DeviceReader = rs
+	for _, want := range []int{2, 1} {
+		got, err := src.ReadAll()
+		if err != nil || len(got) != want {
+			t.Fatalf("ReadAll = %d readings, %v; want %d, nil", len(got), err, want)
+		}
+	}
+	if _, err := src.ReadAll(); !errors.Is(err, io.EOF) {
+		t.Errorf("ReadAll past the end = %v, want io.EOF", err)
+	}
+
+	rs.Rewind()
+	var times []time.Time
+	for res := range rs.Poll(context.Background(), 0) {
+		times = append(times, res.Time)
+	}
+	if len(times) != 2 || !times[0].Equal(at) || !times[1].Equal(at.Add(time.Minute)) {
+		t.Errorf("Poll after Rewind sent reads at %v, want %v and a minute later", times, at)
+	}
+}
+
+func TestReplaySourcePollStopsOnCancel(t *testing.T) {
+	at := time.Unix(1700000000, 0).UTC()
+	var buf bytes.Buffer
+	WriteNDJSON(&buf, []Reading{{Time: at}, {Time: at.Add(time.Hour)}})
+	rs, err := NewReplaySource(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := rs.Poll(ctx, 1)
+	<-ch
+	cancel()
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Error("Poll sent the hour-later read instead of stopping")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Poll did not close its channel after cancel")
+	}
+}
@@ -0,0 +1,94 @@
+package temperedgo
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// DeviceListRetry enumerates up to attempts times, sleeping delay between
+// tries, until an enumeration succeeds with at least one device. This covers
+// the HID layer briefly reporting nothing at boot. Once attempts are
+// exhausted it returns the last result, which may be an empty list or an
+// error.
+func (t *Tempered) DeviceListRetry(attempts int, delay time.Duration) ([]TemperedDevice, error) {
+	var tds []TemperedDevice
+	var err error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			time.Sleep(delay)
+		}
+		tds, err = t.DeviceList()
+		if err == nil && len(tds) > 0 {
+			break
+		}
+	}
+	return tds, err
+}
+
+// permanentReadError reports whether err is a caller error that retrying
+// can't fix.
+func permanentReadError(err error) bool {
+	return errors.Is(err, ERR_NOT_OPEN) || errors.Is(err, ERR_NOT_INITED) ||
+		errors.Is(err, ERR_SENSOR_OUT_OF_RANGE) ||
+		errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// retryRead updates the device and calls read up to attempts times, at least
+// once, sleeping backoff between tries, until both succeed. It stops early on
+// a permanent error or when ctx is done, and otherwise returns the last
+// error.
+func (t *TemperedDevice) retryRead(ctx context.Context, attempts int, backoff time.Duration, read func(ctx context.Context) (float64, error)) (float64, error) {
+	var err error
+	for i := 0; i < attempts || i == 0; i++ {
+		if i > 0 {
+			timer := time.NewTimer(backoff)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return 0, ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		if err = t.UpdateContext(ctx); err == nil {
+			var val float64
+			if val, err = read(ctx); err == nil {
+				return val, nil
+			}
+		}
+		if permanentReadError(err) {
+			return 0, err
+		}
+	}
+	return 0, err
+}
+
+// TemperatureRetry updates the device and reads sensorNum's temperature,
+// retrying up to attempts times with backoff between tries to ride out
+// transient failures such as ERR_FAILED_RETRIEVE. It doesn't retry errors
+// that can't be transient, such as ERR_NOT_OPEN.
+func (t *TemperedDevice) TemperatureRetry(sensorNum, attempts int, backoff time.Duration) (Celsius, error) {
+	return t.TemperatureRetryContext(context.Background(), sensorNum, attempts, backoff)
+}
+
+// TemperatureRetryContext is TemperatureRetry, giving up when ctx is done.
+func (t *TemperedDevice) TemperatureRetryContext(ctx context.Context, sensorNum, attempts int, backoff time.Duration) (Celsius, error) {
+	val, err := t.retryRead(ctx, attempts, backoff, func(ctx context.Context) (float64, error) {
+		tempC, err := t.TemperatureContext(ctx, sensorNum)
+		return tempC.Float64(), err
+	})
+	return Celsius(val), err
+}
+
+// HumidityRetry is TemperatureRetry for humidity.
+func (t *TemperedDevice) HumidityRetry(sensorNum, attempts int, backoff time.Duration) (float64, error) {
+	return t.HumidityRetryContext(context.Background(), sensorNum, attempts, backoff)
+}
+
+// HumidityRetryContext is HumidityRetry, giving up when ctx is done.
+func (t *TemperedDevice) HumidityRetryContext(ctx context.Context, sensorNum, attempts int, backoff time.Duration) (float64, error) {
+	return t.retryRead(ctx, attempts, backoff, func(ctx context.Context) (float64, error) {
+		return t.HumidityContext(ctx, sensorNum)
+	})
+}
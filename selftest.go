@@ -0,0 +1,85 @@
+package temperedgo
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	selfTestSamples  = 5
+	selfTestInterval = time.Second
+)
+
+// SelfTest opens the device if needed, reads it selfTestSamples times and
+// checks that each read succeeds, every value is plausible and that no
+// sensor's value is exactly the same on every read, which is how a stuck
+// sensor usually shows itself. A device that SelfTest opened is closed again
+// afterwards. The failing check is identified in the returned error, which
+// wraps ERR_IMPLAUSIBLE or ERR_SENSOR_FROZEN where applicable.
+//
+// A sensor in a very stable environment can legitimately repeat its value,
+// so a frozen result is a reason to look closer rather than proof of a fault.
+func (t *TemperedDevice) SelfTest() (err error) {
+	if err := acquireLib(t.backendOrDefault()); err != nil {
+		return t.deviceError(err)
+	}
+	t.lockDevice()
+	wasOpen := t.dev != nil
+	t.unlockDevice()
+	libLock.RUnlock()
+
+	if !wasOpen {
+		if err := t.Open(); err != nil {
+			return err
+		}
+		defer func() {
+			err = errors.Join(err, t.Close())
+		}()
+	}
+
+	var first []Reading
+	changed := map[int]bool{}
+	for i := 0; i < selfTestSamples; i++ {
+		if i > 0 {
+			time.Sleep(selfTestInterval)
+		}
+
+		rs, err := t.ReadAll()
+		if err != nil {
+			return fmt.Errorf("self-test read %d: %w", i+1, err)
+		}
+		for _, r := range rs {
+			if err := checkPlausible(r); err != nil {
+				return t.deviceError(fmt.Errorf("self-test: %w", err))
+			}
+		}
+
+		if first == nil {
+			first = rs
+			continue
+		}
+		if len(rs) != len(first) {
+			return t.deviceError(fmt.Errorf("self-test: sensor count changed from %d to %d", len(first), len(rs)))
+		}
+		for n, r := range rs {
+			if !sameValue(r.Temperature, first[n].Temperature) || !sameValue(r.Humidity, first[n].Humidity) {
+				changed[n] = true
+			}
+		}
+	}
+
+	for n := range first {
+		if !changed[n] {
+			return t.sensorError(n, fmt.Errorf("self-test: identical over %d reads: %w", selfTestSamples, ERR_SENSOR_FROZEN))
+		}
+	}
+	return nil
+}
+
+func sameValue(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,184 @@
+package temperedgo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var sensorTypeNames = []struct {
+	t    TemperedSensorType
+	name string
+}{
+	{TEMPERED_SENSOR_TYPE_TEMPERATURE, "temperature"},
+	{TEMPERED_SENSOR_TYPE_HUMIDITY, "humidity"},
+}
+
+// Names returns the human-readable name of each known measurement st
+// includes.
+func (st TemperedSensorType) Names() []string {
+	names := []string{}
+	for _, tn := range sensorTypeNames {
+		if st.IsType(tn.t) {
+			names = append(names, tn.name)
+		}
+	}
+	return names
+}
+
+// AllSensorTypes returns every known measurement, each as its own type.
+func AllSensorTypes() []TemperedSensorType {
+	types := make([]TemperedSensorType, 0, len(sensorTypeNames))
+	for _, tn := range sensorTypeNames {
+		types = append(types, tn.t)
+	}
+	return types
+}
+
+// Types splits st into its individual bits, lowest first, including any no
+// measurement is known for. An empty mask has none.
+func (st TemperedSensorType) Types() []TemperedSensorType {
+	types := []TemperedSensorType{}
+	for bit := TemperedSensorType(1); bit != 0 && st&^(bit-1) != 0; bit <<= 1 {
+		if st&bit != 0 {
+			types = append(types, bit)
+		}
+	}
+	return types
+}
+
+// typeTokens lists the names of the measurements in st, followed by any bits
+// no measurement is known for as a single hex token such as "0x4".
+func (st TemperedSensorType) typeTokens() []string {
+	tokens := st.Names()
+	if rest := st &^ TEMPERED_SENSOR_TYPE_ALL; rest != 0 {
+		tokens = append(tokens, fmt.Sprintf("0x%x", uint(rest)))
+	}
+	return tokens
+}
+
+// String joins the measurements in st with "+", as in
+// "temperature+humidity". An empty mask is "none".
+func (st TemperedSensorType) String() string {
+	tokens := st.typeTokens()
+	if len(tokens) == 0 {
+		return "none"
+	}
+	return strings.Join(tokens, "+")
+}
+
+// MarshalJSON encodes st as an array of measurement names, as in
+// ["temperature","humidity"].
+func (st TemperedSensorType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(st.typeTokens())
+}
+
+// UnmarshalJSON accepts what MarshalJSON produces, and also the plain integer
+// mask older encodings used.
+func (st *TemperedSensorType) UnmarshalJSON(data []byte) error {
+	var mask int
+	if err := json.Unmarshal(data, &mask); err == nil {
+		*st = TemperedSensorType(mask)
+		return nil
+	}
+
+	var tokens []string
+	if err := json.Unmarshal(data, &tokens); err != nil {
+		return fmt.Errorf("tempered: sensor type must be an array of names or an integer: %w", err)
+	}
+	var t TemperedSensorType
+tokens:
+	for _, token := range tokens {
+		for _, tn := range sensorTypeNames {
+			if token == tn.name {
+				t |= tn.t
+				continue tokens
+			}
+		}
+		bits, err := strconv.ParseUint(token, 0, 0)
+		if err != nil || !strings.HasPrefix(token, "0x") {
+			return fmt.Errorf("tempered: unknown sensor type %q", token)
+		}
+		t |= TemperedSensorType(bits)
+	}
+	*st = t
+	return nil
+}
+
+type SensorInfo struct {
+	Index int
+	Types []string
+}
+
+func (t *TemperedDevice) DescribeSensors() ([]SensorInfo, error) {
+	sensors, err := t.Sensors()
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]SensorInfo, 0, len(sensors))
+	for _, ts := range sensors {
+		infos = append(infos, SensorInfo{Index: ts.sensorNum, Types: ts.TypeMask.Names()})
+	}
+	return infos, nil
+}
+
+// CountByType returns how many of the device's sensors support every
+// measurement in st.
+func (t *TemperedDevice) CountByType(st TemperedSensorType) (int, error) {
+	sensors, err := t.Sensors()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, ts := range sensors {
+		if ts.TypeMask.IsType(st) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// GlobalSensor is a sensor addressed across several devices. ID is
+// "<device ID>#<sensor number>", stable for as long as the device ID is;
+// GlobalIndex is its position in the list AllSensors returned.
+type GlobalSensor struct {
+	ID          string
+	GlobalIndex int
+	DeviceID    string
+	SensorNum   int
+	Type        TemperedSensorType
+	Sensor      *TemperedSensor
+}
+
+// AllSensors lists the sensors of every device in devices, in device order
+// and then sensor number order. With ERROR_MODE_COLLECT a device whose
+// sensors can't be listed is skipped and its error joined into the result.
+func (t *Tempered) AllSensors(devices []*TemperedDevice) ([]GlobalSensor, error) {
+	gss := []GlobalSensor{}
+	var errs []error
+	for _, td := range devices {
+		sensors, err := td.SortedSensors()
+		if err != nil {
+			if t.ErrorMode == ERROR_MODE_FAIL_FAST {
+				return nil, err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		for _, ts := range sensors {
+			gss = append(gss, GlobalSensor{
+				ID:          fmt.Sprintf("%s#%d", td.ID(), ts.sensorNum),
+				GlobalIndex: len(gss),
+				DeviceID:    td.ID(),
+				SensorNum:   ts.sensorNum,
+				Type:        ts.TypeMask,
+				Sensor:      ts,
+			})
+		}
+	}
+	return gss, errors.Join(errs...)
+}
@@ -0,0 +1,120 @@
+package temperedgo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSensorTypeAll(t *testing.T) {
+	if TEMPERED_SENSOR_TYPE_ALL != TEMPERED_SENSOR_TYPE_TEMPERATURE|TEMPERED_SENSOR_TYPE_HUMIDITY {
+		t.Fatalf("TEMPERED_SENSOR_TYPE_ALL = %d, want temperature|humidity", TEMPERED_SENSOR_TYPE_ALL)
+	}
+
+	for _, tc := range []struct {
+		st   TemperedSensorType
+		want bool
+	}{
+		{TEMPERED_SENSOR_TYPE_TEMPERATURE, false},
+		{TEMPERED_SENSOR_TYPE_HUMIDITY, false},
+		{TEMPERED_SENSOR_TYPE_ALL, true},
+		{TEMPERED_SENSOR_TYPE_ALL | 4, true},
+	} {
+		if got := tc.st.IsType(TEMPERED_SENSOR_TYPE_ALL); got != tc.want {
+			t.Errorf("TemperedSensorType(%d).IsType(ALL) = %v, want %v", int(tc.st), got, tc.want)
+		}
+	}
+
+	var all TemperedSensorType = TEMPERED_SENSOR_TYPE_ALL
+	if !all.IsType(TEMPERED_SENSOR_TYPE_TEMPERATURE) || !all.IsType(TEMPERED_SENSOR_TYPE_HUMIDITY) {
+		t.Error("ALL does not include each of its measurements")
+	}
+	if all.IsExactly(TEMPERED_SENSOR_TYPE_TEMPERATURE) {
+		t.Error("ALL.IsExactly(TEMPERATURE) = true")
+	}
+}
+
+func TestSensorTypeIsType(t *testing.T) {
+	const unknown TemperedSensorType = 1 << 5
+	for _, tc := range []struct {
+		st, query TemperedSensorType
+		want      bool
+	}{
+		// An empty query never matches, whatever the mask.
+		{TEMPERED_SENSOR_TYPE_NONE, TEMPERED_SENSOR_TYPE_NONE, false},
+		{TEMPERED_SENSOR_TYPE_TEMPERATURE, TEMPERED_SENSOR_TYPE_NONE, false},
+		{TEMPERED_SENSOR_TYPE_ALL, TEMPERED_SENSOR_TYPE_NONE, false},
+		{TEMPERED_SENSOR_TYPE_ALL | unknown, TEMPERED_SENSOR_TYPE_NONE, false},
+
+		// An empty mask has no measurements.
+		{TEMPERED_SENSOR_TYPE_NONE, TEMPERED_SENSOR_TYPE_TEMPERATURE, false},
+		{TEMPERED_SENSOR_TYPE_NONE, TEMPERED_SENSOR_TYPE_ALL, false},
+
+		{TEMPERED_SENSOR_TYPE_TEMPERATURE, TEMPERED_SENSOR_TYPE_TEMPERATURE, true},
+		{TEMPERED_SENSOR_TYPE_TEMPERATURE, TEMPERED_SENSOR_TYPE_HUMIDITY, false},
+		{TEMPERED_SENSOR_TYPE_HUMIDITY, TEMPERED_SENSOR_TYPE_HUMIDITY, true},
+		{TEMPERED_SENSOR_TYPE_ALL, TEMPERED_SENSOR_TYPE_TEMPERATURE, true},
+		{TEMPERED_SENSOR_TYPE_ALL, TEMPERED_SENSOR_TYPE_HUMIDITY, true},
+
+		// Unknown bits are matched like any other.
+		{unknown, unknown, true},
+		{unknown, TEMPERED_SENSOR_TYPE_TEMPERATURE, false},
+		{TEMPERED_SENSOR_TYPE_TEMPERATURE, unknown, false},
+		{TEMPERED_SENSOR_TYPE_TEMPERATURE | unknown, unknown, true},
+		{TEMPERED_SENSOR_TYPE_ALL, TEMPERED_SENSOR_TYPE_ALL | unknown, false},
+	} {
+		if got := tc.st.IsType(tc.query); got != tc.want {
+			t.Errorf("%v.IsType(%v) = %v, want %v", tc.st, tc.query, got, tc.want)
+		}
+	}
+}
+
+func TestSensorTypeTypes(t *testing.T) {
+	const unknown TemperedSensorType = 1 << 5
+	for _, tc := range []struct {
+		st   TemperedSensorType
+		want []TemperedSensorType
+	}{
+		{TEMPERED_SENSOR_TYPE_NONE, nil},
+		{TEMPERED_SENSOR_TYPE_TEMPERATURE, []TemperedSensorType{TEMPERED_SENSOR_TYPE_TEMPERATURE}},
+		{TEMPERED_SENSOR_TYPE_HUMIDITY, []TemperedSensorType{TEMPERED_SENSOR_TYPE_HUMIDITY}},
+		{TEMPERED_SENSOR_TYPE_ALL, []TemperedSensorType{TEMPERED_SENSOR_TYPE_TEMPERATURE, TEMPERED_SENSOR_TYPE_HUMIDITY}},
+		{TEMPERED_SENSOR_TYPE_HUMIDITY | unknown, []TemperedSensorType{TEMPERED_SENSOR_TYPE_HUMIDITY, unknown}},
+		{1 << 30, []TemperedSensorType{1 << 30}},
+	} {
+		got := tc.st.Types()
+		if len(got) != len(tc.want) {
+			t.Errorf("%v.Types() = %v, want %v", tc.st, got, tc.want)
+			continue
+		}
+		for n := range got {
+			if got[n] != tc.want[n] {
+				t.Errorf("%v.Types() = %v, want %v", tc.st, got, tc.want)
+				break
+			}
+		}
+	}
+}
+
+func TestSensorTypeJSONRoundTrip(t *testing.T) {
+	const unknown TemperedSensorType = 1 << 5
+	for _, st := range []TemperedSensorType{
+		TEMPERED_SENSOR_TYPE_NONE,
+		TEMPERED_SENSOR_TYPE_TEMPERATURE,
+		TEMPERED_SENSOR_TYPE_ALL,
+		TEMPERED_SENSOR_TYPE_ALL | unknown,
+	} {
+		data, err := json.Marshal(st)
+		if err != nil {
+			t.Errorf("Marshal(%v): %v", st, err)
+			continue
+		}
+		var got TemperedSensorType
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Errorf("Unmarshal(%s): %v", data, err)
+			continue
+		}
+		if got != st {
+			t.Errorf("%v round-tripped through %s to %v", st, data, got)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package temperedgo
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// Track registers open devices to be closed by CloseTracked, and so by the
+// handler InstallSignalHandler installs.
+func (t *Tempered) Track(devs ...*TemperedDevice) {
+	t.trackLock.Lock()
+	defer t.trackLock.Unlock()
+
+	t.tracked = append(t.tracked, devs...)
+}
+
+// Untrack removes devices registered with Track.
+func (t *Tempered) Untrack(devs ...*TemperedDevice) {
+	t.trackLock.Lock()
+	defer t.trackLock.Unlock()
+
+	kept := t.tracked[:0]
+	for _, td := range t.tracked {
+		drop := false
+		for _, dev := range devs {
+			if td == dev {
+				drop = true
+				break
+			}
+		}
+		if !drop {
+			kept = append(kept, td)
+		}
+	}
+	t.tracked = kept
+}
+
+// CloseTracked closes and forgets every device registered with Track.
+func (t *Tempered) CloseTracked() error {
+	t.trackLock.Lock()
+	tracked := t.tracked
+	t.tracked = nil
+	t.trackLock.Unlock()
+
+	var errs []error
+	for _, td := range tracked {
+		if err := td.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// exitAll calls Exit until every Init by t has been undone.
+func (t *Tempered) exitAll() error {
+	for {
+		libLock.RLock()
+		refs := t.refs
+		libLock.RUnlock()
+		if refs == 0 {
+			return nil
+		}
+		if err := t.Exit(); err != nil {
+			return err
+		}
+	}
+}
+
+// InstallSignalHandler closes the tracked devices and calls Exit, as many
+// times as t was inited, when one of signals arrives. It then uninstalls
+// itself and raises the signal again, so that it gets whatever handling it
+// would have had without the handler: by default the process ends. The
+// returned function uninstalls the handler without waiting for a signal.
+// This is optional: callers that already manage their own signal handling
+// should call CloseTracked and Exit themselves instead.
+func (t *Tempered) InstallSignalHandler(signals ...os.Signal) func() {
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, signals...)
+
+	go func() {
+		select {
+		case sig := <-ch:
+			t.CloseTracked()
+			t.exitAll()
+			signal.Stop(ch)
+			if p, err := os.FindProcess(os.Getpid()); err == nil {
+				p.Signal(sig)
+			}
+		case <-done:
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(ch)
+			close(done)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+//go:build unix
+
+package temperedgo
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestSignalHandlerCleansUpAndReraises(t *testing.T) {
+	fb := &FakeBackend{Devices: []*FakeDevice{{
+		Path:    "/dev/fake0",
+		Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_TEMPERATURE}},
+	}}}
+	tm := &Tempered{Backend: fb}
+	if err := tm.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer tm.exitAll()
+	tds, err := tm.DeviceList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	td := &tds[0]
+	if err := td.Open(); err != nil {
+		t.Fatal(err)
+	}
+	tm.Track(td)
+
+	// The caller's own handler stands in for the default action, which
+	// would end the test binary.
+	caller := make(chan os.Signal, 2)
+	signal.Notify(caller, syscall.SIGUSR1)
+	defer signal.Stop(caller)
+
+	uninstall := tm.InstallSignalHandler(syscall.SIGUSR1)
+	defer uninstall()
+
+	syscall.Kill(os.Getpid(), syscall.SIGUSR1)
+
+	// The caller sees the original signal and then the re-raised one.
+	for n := 0; n < 2; n++ {
+		select {
+		case <-caller:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("caller got %d signals, want 2", n)
+		}
+	}
+	if n := fb.OpenHandles(); n != 0 {
+		t.Errorf("OpenHandles() = %d after the signal, want 0", n)
+	}
+	if _, err := tm.DeviceList(); err == nil {
+		t.Error("DeviceList succeeded after the signal, want the library exited")
+	}
+}
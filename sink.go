@@ -0,0 +1,38 @@
+package temperedgo
+
+import (
+	"context"
+	"errors"
+)
+
+// Sink is a destination for readings, such as a file, a message broker or
+// a metrics gateway.
+type Sink interface {
+	Write(ctx context.Context, r Reading) error
+	Close() error
+}
+
+// WriteSinks writes every reading to every sink. A failing sink doesn't
+// stop the others; the failures are joined into the returned error.
+func WriteSinks(ctx context.Context, sinks []Sink, rs []Reading) error {
+	var errs []error
+	for _, sink := range sinks {
+		for _, r := range rs {
+			if err := sink.Write(ctx, r); err != nil {
+				errs = append(errs, err)
+				break
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func closeSinks(sinks []Sink) error {
+	var errs []error
+	for _, sink := range sinks {
+		if err := sink.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
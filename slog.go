@@ -0,0 +1,37 @@
+package temperedgo
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogObserver is an Observer that logs each operation to Logger: failures
+// at error level and successes at debug, with the device, operation, sensor,
+// duration and either the temperature, humidity or error as attributes.
+type SlogObserver struct {
+	Logger *slog.Logger
+}
+
+func (o SlogObserver) ObserveOperation(ev OperationEvent) {
+	attrs := []slog.Attr{
+		slog.String("device", ev.DeviceID),
+		slog.String("op", ev.Op),
+		slog.Duration("duration", ev.Duration),
+	}
+	if ev.SensorNum >= 0 {
+		attrs = append(attrs, slog.Int("sensor", ev.SensorNum))
+	}
+
+	if ev.Err != nil {
+		attrs = append(attrs, slog.Any("error", ev.Err))
+		o.Logger.LogAttrs(context.Background(), slog.LevelError, "tempered operation failed", attrs...)
+		return
+	}
+	switch ev.Op {
+	case OP_TEMPERATURE:
+		attrs = append(attrs, slog.Float64("temperature", ev.Value))
+	case OP_HUMIDITY:
+		attrs = append(attrs, slog.Float64("humidity", ev.Value))
+	}
+	o.Logger.LogAttrs(context.Background(), slog.LevelDebug, "tempered operation", attrs...)
+}
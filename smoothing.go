@@ -0,0 +1,179 @@
+package temperedgo
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+const DEFAULT_MEDIAN_WINDOW = 5
+
+// MedianSensor wraps a sensor and returns the median of its most recent
+// samples, rejecting spikes without the lag bias of an average. Every read
+// takes a fresh sample from the sensor; until the window fills, the median is
+// over however many samples are available.
+type MedianSensor struct {
+	sensor *TemperedSensor
+	window int
+
+	lock  sync.Mutex
+	temps []float64
+	hums  []float64
+}
+
+// NewMedianSensor wraps ts with a window of n samples, or
+// DEFAULT_MEDIAN_WINDOW if n is not positive.
+func NewMedianSensor(ts *TemperedSensor, n int) *MedianSensor {
+	if n <= 0 {
+		n = DEFAULT_MEDIAN_WINDOW
+	}
+	return &MedianSensor{sensor: ts, window: n}
+}
+
+// SamplesForWindow is how many samples taken every interval span window,
+// rounding up and never less than one.
+func SamplesForWindow(window, interval time.Duration) int {
+	if interval <= 0 {
+		return 1
+	}
+	n := int((window + interval - 1) / interval)
+	if n < 1 {
+		return 1
+	}
+	return n
+}
+
+// WindowForSamples is the time n samples taken every interval span.
+func WindowForSamples(n int, interval time.Duration) time.Duration {
+	return time.Duration(n) * interval
+}
+
+// NewMedianSensorWindow is NewMedianSensor with the window given as a time
+// span, for a sensor read every interval.
+func NewMedianSensorWindow(ts *TemperedSensor, window, interval time.Duration) *MedianSensor {
+	return NewMedianSensor(ts, SamplesForWindow(window, interval))
+}
+
+func pushSample(samples []float64, val float64, window int) []float64 {
+	samples = append(samples, val)
+	if len(samples) > window {
+		samples = samples[len(samples)-window:]
+	}
+	return samples
+}
+
+func median(samples []float64) float64 {
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func (ms *MedianSensor) Temperature() (Celsius, error) {
+	val, err := ms.sensor.Temperature()
+	if err != nil {
+		return 0, err
+	}
+
+	ms.lock.Lock()
+	defer ms.lock.Unlock()
+	ms.temps = pushSample(ms.temps, val.Float64(), ms.window)
+	return Celsius(median(ms.temps)), nil
+}
+
+func (ms *MedianSensor) Humidity() (float64, error) {
+	val, err := ms.sensor.Humidity()
+	if err != nil {
+		return 0, err
+	}
+
+	ms.lock.Lock()
+	defer ms.lock.Unlock()
+	ms.hums = pushSample(ms.hums, val, ms.window)
+	return median(ms.hums), nil
+}
+
+type timedSample struct {
+	time time.Time
+	val  float64
+}
+
+// WeightedAverageSensor wraps a sensor and returns an average of its
+// samples from the last window, each weighted linearly by age from 1 for a
+// sample taken now down to 0 at the edge of the window. Recent changes
+// dominate without the jump a plain reading would show. Every read takes a
+// fresh sample; until minSamples samples are in the window the latest
+// sample is returned unsmoothed.
+type WeightedAverageSensor struct {
+	sensor     *TemperedSensor
+	window     time.Duration
+	minSamples int
+
+	lock  sync.Mutex
+	temps []timedSample
+	hums  []timedSample
+}
+
+// NewWeightedAverageSensor returns an error if window isn't positive, as no
+// sample would ever fall inside it.
+func NewWeightedAverageSensor(ts *TemperedSensor, window time.Duration, minSamples int) (*WeightedAverageSensor, error) {
+	if window <= 0 {
+		return nil, errors.New("tempered: averaging window must be positive")
+	}
+	return &WeightedAverageSensor{sensor: ts, window: window, minSamples: minSamples}, nil
+}
+
+// push adds a sample taken now, drops those that have aged out of the
+// window and returns the weighted average.
+func (ws *WeightedAverageSensor) push(samples []timedSample, val float64) ([]timedSample, float64) {
+	now := time.Now()
+	samples = append(samples, timedSample{now, val})
+	kept := samples[:0]
+	for _, s := range samples {
+		if now.Sub(s.time) < ws.window {
+			kept = append(kept, s)
+		}
+	}
+	samples = kept
+	if len(samples) < ws.minSamples {
+		return samples, val
+	}
+
+	var sum, weights float64
+	for _, s := range samples {
+		w := 1 - float64(now.Sub(s.time))/float64(ws.window)
+		sum += w * s.val
+		weights += w
+	}
+	return samples, sum / weights
+}
+
+func (ws *WeightedAverageSensor) Temperature() (Celsius, error) {
+	val, err := ws.sensor.Temperature()
+	if err != nil {
+		return 0, err
+	}
+
+	ws.lock.Lock()
+	defer ws.lock.Unlock()
+	var avg float64
+	ws.temps, avg = ws.push(ws.temps, val.Float64())
+	return Celsius(avg), nil
+}
+
+func (ws *WeightedAverageSensor) Humidity() (float64, error) {
+	val, err := ws.sensor.Humidity()
+	if err != nil {
+		return 0, err
+	}
+
+	ws.lock.Lock()
+	defer ws.lock.Unlock()
+	var avg float64
+	ws.hums, avg = ws.push(ws.hums, val)
+	return avg, nil
+}
@@ -0,0 +1,107 @@
+// Package sqlite stores readings in a SQLite database.
+//
+// It uses database/sql and takes an already open *sql.DB, so it does not
+// pick a driver: import one, such as modernc.org/sqlite (pure Go) or
+// github.com/mattn/go-sqlite3, and open the database with it.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	temperedgo "github.com/lukegb/tempered-go"
+)
+
+const schema = `CREATE TABLE IF NOT EXISTS readings (
+	timestamp INTEGER NOT NULL,
+	device TEXT NOT NULL,
+	sensor INTEGER NOT NULL,
+	temperature REAL,
+	humidity REAL
+);
+CREATE INDEX IF NOT EXISTS readings_timestamp ON readings (timestamp);`
+
+const insertReading = `INSERT INTO readings (timestamp, device, sensor, temperature, humidity) VALUES (?, ?, ?, ?, ?)`
+
+type Store struct {
+	db *sql.DB
+}
+
+// New creates the readings table in db if it doesn't already exist.
+// Timestamps are stored as Unix nanoseconds.
+func New(db *sql.DB) (*Store, error) {
+	if _, err := db.Exec(schema); err != nil {
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+func nullable(val *float64) sql.NullFloat64 {
+	if val == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *val, Valid: true}
+}
+
+func (s *Store) Insert(r temperedgo.Reading) error {
+	_, err := s.db.Exec(insertReading, r.Time.UnixNano(), r.DeviceID, r.SensorNum, nullable(r.Temperature), nullable(r.Humidity))
+	return err
+}
+
+// InsertBatch inserts every reading in a single transaction, so either all
+// of them are stored or none are.
+func (s *Store) InsertBatch(ctx context.Context, rs []temperedgo.Reading) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, insertReading)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, r := range rs {
+		if _, err := stmt.ExecContext(ctx, r.Time.UnixNano(), r.DeviceID, r.SensorNum, nullable(r.Temperature), nullable(r.Humidity)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Query returns the readings taken at or after since, oldest first. Only
+// the stored columns are filled in; in particular Type reflects which
+// measurements are present rather than the sensor's full capabilities.
+func (s *Store) Query(since time.Time) ([]temperedgo.Reading, error) {
+	rows, err := s.db.Query(`SELECT timestamp, device, sensor, temperature, humidity FROM readings WHERE timestamp >= ? ORDER BY timestamp`, since.UnixNano())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rs := []temperedgo.Reading{}
+	for rows.Next() {
+		var ts int64
+		var r temperedgo.Reading
+		var temp, hum sql.NullFloat64
+		if err := rows.Scan(&ts, &r.DeviceID, &r.SensorNum, &temp, &hum); err != nil {
+			return nil, err
+		}
+		r.Time = time.Unix(0, ts)
+		if temp.Valid {
+			val := temp.Float64
+			r.Temperature = &val
+			r.Type |= temperedgo.TEMPERED_SENSOR_TYPE_TEMPERATURE
+		}
+		if hum.Valid {
+			val := hum.Float64
+			r.Humidity = &val
+			r.Type |= temperedgo.TEMPERED_SENSOR_TYPE_HUMIDITY
+		}
+		rs = append(rs, r)
+	}
+	return rs, rows.Err()
+}
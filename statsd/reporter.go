@@ -0,0 +1,96 @@
+// Package statsd periodically reports readings as StatsD gauges.
+package statsd
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	temperedgo "github.com/lukegb/tempered-go"
+)
+
+// Client is the gauge method of a DogStatsD client, such as the one in
+// github.com/DataDog/datadog-go/v5/statsd.
+type Client interface {
+	Gauge(name string, value float64, tags []string, rate float64) error
+}
+
+const DEFAULT_INTERVAL = 10 * time.Second
+
+type Reporter struct {
+	Client   Client
+	Tempered *temperedgo.Tempered
+
+	// Interval is how often to report. Zero means DEFAULT_INTERVAL.
+	Interval time.Duration
+
+	// OnError, if set, is called with each device read or client send
+	// error. Errors never stop the reporter.
+	OnError func(error)
+}
+
+// Run reports every Interval until ctx is done. A negative Interval is an
+// error.
+func (r *Reporter) Run(ctx context.Context) error {
+	interval := r.Interval
+	if interval == 0 {
+		interval = DEFAULT_INTERVAL
+	}
+	if interval < 0 {
+		return errors.New("statsd: report interval must not be negative")
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		r.report()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Reporter) handleError(err error) {
+	if err != nil && r.OnError != nil {
+		r.OnError(err)
+	}
+}
+
+func (r *Reporter) report() {
+	drs, err := r.Tempered.ReadAllFlat()
+	if err != nil {
+		r.handleError(err)
+		return
+	}
+
+	for _, dr := range drs {
+		if dr.Err != nil {
+			r.handleError(dr.Err)
+			continue
+		}
+		tags := []string{"device:" + dr.DeviceID, "sensor:" + strconv.Itoa(dr.SensorNum)}
+		if dr.Label != "" {
+			tags = append(tags, "label:"+dr.Label)
+		}
+		keys := make([]string, 0, len(dr.Tags))
+		for k := range dr.Tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			tags = append(tags, k+":"+dr.Tags[k])
+		}
+		if dr.Temperature != nil {
+			r.handleError(r.Client.Gauge("tempered.temperature", *dr.Temperature, tags, 1))
+		}
+		if dr.Humidity != nil {
+			r.handleError(r.Client.Gauge("tempered.humidity", *dr.Humidity, tags, 1))
+		}
+	}
+}
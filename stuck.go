@@ -0,0 +1,65 @@
+package temperedgo
+
+import (
+	"sync"
+	"time"
+)
+
+type stuckSeries struct {
+	last  Reading
+	count int
+	since time.Time
+}
+
+// StuckDetector watches readings for sensors whose values stop changing at
+// all, which real sensors never do for long since they always jitter a
+// little. A sensor is stuck once it has reported identical values for
+// Samples consecutive readings or for Window, whichever comes first; a zero
+// limit is not applied. It is safe for concurrent use.
+type StuckDetector struct {
+	Samples int
+	Window  time.Duration
+
+	lock   sync.Mutex
+	series map[seriesKey]*stuckSeries
+}
+
+func NewStuckDetector(samples int, window time.Duration) *StuckDetector {
+	return &StuckDetector{Samples: samples, Window: window}
+}
+
+// Add records r and reports whether its sensor is now stuck. Any change in
+// value clears the sensor's stuck state.
+func (sd *StuckDetector) Add(r Reading) bool {
+	sd.lock.Lock()
+	defer sd.lock.Unlock()
+
+	if sd.series == nil {
+		sd.series = map[seriesKey]*stuckSeries{}
+	}
+	key := seriesKey{r.DeviceID, r.SensorNum}
+	s, ok := sd.series[key]
+	if !ok || !sameValue(r.Temperature, s.last.Temperature) || !sameValue(r.Humidity, s.last.Humidity) {
+		s = &stuckSeries{since: r.Time}
+		sd.series[key] = s
+	}
+	s.last = r
+	s.count++
+	return sd.stuck(s)
+}
+
+func (sd *StuckDetector) stuck(s *stuckSeries) bool {
+	if sd.Samples > 0 && s.count >= sd.Samples {
+		return true
+	}
+	return sd.Window > 0 && s.last.Time.Sub(s.since) >= sd.Window
+}
+
+// Stuck reports whether the sensor was stuck as of its last reading.
+func (sd *StuckDetector) Stuck(deviceID string, sensorNum int) bool {
+	sd.lock.Lock()
+	defer sd.lock.Unlock()
+
+	s, ok := sd.series[seriesKey{deviceID, sensorNum}]
+	return ok && sd.stuck(s)
+}
@@ -0,0 +1,45 @@
+package temperedgo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Summary initialises the library, reads every device and returns a
+// human-readable report with one line per sensor. Devices that fail to read
+// get a line describing the error; finding no devices at all is not an error.
+func Summary() (string, error) {
+	t := new(Tempered)
+	if err := t.Init(); err != nil {
+		return "", err
+	}
+	defer t.Exit()
+
+	tds, err := t.DeviceList()
+	if err != nil {
+		return "", err
+	}
+	if len(tds) == 0 {
+		return "no devices found\n", nil
+	}
+
+	var b strings.Builder
+	for _, td := range tds {
+		rs, err := readDevice(td)
+		if err != nil {
+			fmt.Fprintf(&b, "%s (%s): error: %v\n", td.Path, td.TypeName, err)
+			continue
+		}
+		for _, r := range rs {
+			fmt.Fprintf(&b, "%s (%s) sensor %d:", td.Path, td.TypeName, r.SensorNum)
+			if r.Temperature != nil {
+				fmt.Fprintf(&b, " temperature %.2f%s", *r.Temperature, MEASUREMENT_UNIT_CELSIUS)
+			}
+			if r.Humidity != nil {
+				fmt.Fprintf(&b, " humidity %.2f%s", *r.Humidity, MEASUREMENT_UNIT_RELATIVE_HUMIDITY)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,235 @@
+package temperedgo
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Supervisor keeps every device matching a filter open and polls it at a
+// fixed interval. Devices are re-enumerated on every poll, so hotplugged
+// devices are picked up and unplugged ones dropped; a device that fails to
+// read is reset, and if that fails it is closed and reopened on a later poll.
+//
+// The Supervisor owns the Tempered it is given: shutting it down closes its
+// devices and then calls Exit.
+type Supervisor struct {
+	tempered *Tempered
+	filter   DeviceFilter
+	interval time.Duration
+
+	// Sinks, if set before Run, receive every successful reading. The
+	// Supervisor owns them too, closing them on shutdown. OnSinkError, if
+	// set, is called with each sink write error.
+	Sinks       []Sink
+	OnSinkError func(error)
+
+	// pollLock is held for the duration of each poll and of Close, so
+	// shutdown waits for an in-flight poll and no poll starts after it.
+	pollLock sync.Mutex
+	closed   bool
+
+	lock      sync.Mutex
+	devices   map[string]*TemperedDevice
+	latest    map[string][]Reading
+	produced  uint64
+	sinkStats []DeliveryStats
+}
+
+// NewSupervisor returns a Supervisor for devices on t, which must already be
+// initialised, that match filter, polling every interval.
+func NewSupervisor(t *Tempered, filter DeviceFilter, interval time.Duration) (*Supervisor, error) {
+	if interval <= 0 {
+		return nil, errors.New("tempered: supervisor interval must be positive")
+	}
+	return &Supervisor{
+		tempered: t,
+		filter:   filter,
+		interval: interval,
+		devices:  make(map[string]*TemperedDevice),
+		latest:   make(map[string][]Reading),
+	}, nil
+}
+
+// Run polls until ctx is done and then shuts down as Close does, returning
+// the shutdown error if there was one and ctx.Err() otherwise. It returns nil
+// early if Close is called from elsewhere.
+func (s *Supervisor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if !s.poll(ctx) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if err := s.Close(); err != nil {
+				return err
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll returns false if the Supervisor has been closed.
+func (s *Supervisor) poll(ctx context.Context) bool {
+	s.pollLock.Lock()
+	defer s.pollLock.Unlock()
+
+	if s.closed {
+		return false
+	}
+
+	tds, err := s.tempered.DeviceList()
+	if err != nil {
+		return true
+	}
+
+	present := make(map[string]bool)
+	for _, td := range s.filter.Filter(tds) {
+		id := td.ID()
+		present[id] = true
+		if _, ok := s.devices[id]; ok {
+			continue
+		}
+		dev := td
+		if err := dev.Open(); err != nil {
+			continue
+		}
+		s.devices[id] = &dev
+	}
+
+	for id, dev := range s.devices {
+		if !present[id] {
+			dev.Close()
+			s.forget(id)
+			continue
+		}
+
+		rs, err := dev.ReadAll()
+		if err != nil {
+			if dev.Reset() == nil {
+				rs, err = dev.ReadAll()
+			}
+		}
+		if err != nil {
+			dev.Close()
+			s.forget(id)
+			continue
+		}
+
+		s.lock.Lock()
+		s.latest[id] = rs
+		s.produced += uint64(len(rs))
+		s.lock.Unlock()
+
+		s.writeSinks(ctx, rs)
+	}
+
+	return true
+}
+
+// writeSinks is WriteSinks, counting what each sink accepted. A sink that
+// fails is skipped for the rest of rs, which counts as dropped.
+func (s *Supervisor) writeSinks(ctx context.Context, rs []Reading) {
+	for n, sink := range s.Sinks {
+		delivered := 0
+		for _, r := range rs {
+			if err := sink.Write(ctx, r); err != nil {
+				if s.OnSinkError != nil {
+					s.OnSinkError(err)
+				}
+				break
+			}
+			delivered++
+		}
+
+		s.lock.Lock()
+		if len(s.sinkStats) != len(s.Sinks) {
+			s.sinkStats = make([]DeliveryStats, len(s.Sinks))
+		}
+		s.sinkStats[n].Delivered += uint64(delivered)
+		s.sinkStats[n].Dropped += uint64(len(rs) - delivered)
+		s.lock.Unlock()
+	}
+}
+
+// SupervisorStats counts the readings a Supervisor has polled and, indexed
+// as Sinks, how many each sink accepted or dropped after a write error.
+type SupervisorStats struct {
+	Produced uint64
+	Sinks    []DeliveryStats
+}
+
+func (s *Supervisor) Stats() SupervisorStats {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stats := SupervisorStats{
+		Produced: s.produced,
+		Sinks:    make([]DeliveryStats, len(s.Sinks)),
+	}
+	copy(stats.Sinks, s.sinkStats)
+	return stats
+}
+
+func (s *Supervisor) forget(id string) {
+	delete(s.devices, id)
+
+	s.lock.Lock()
+	delete(s.latest, id)
+	s.lock.Unlock()
+}
+
+// Close waits for any in-flight poll, closes every device in order of device
+// ID and then the sinks, and then calls Exit on the Tempered. Failures along
+// the way don't stop the shutdown; they are joined into the returned error.
+// Only the first call does anything.
+func (s *Supervisor) Close() error {
+	s.pollLock.Lock()
+	defer s.pollLock.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+
+	ids := make([]string, 0, len(s.devices))
+	for id := range s.devices {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var errs []error
+	for _, id := range ids {
+		if err := s.devices[id].Close(); err != nil {
+			errs = append(errs, err)
+		}
+		s.forget(id)
+	}
+	if err := closeSinks(s.Sinks); err != nil {
+		errs = append(errs, err)
+	}
+	if err := s.tempered.Exit(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// Latest returns the most recent readings of every device that is currently
+// open and reading successfully, keyed by device ID.
+func (s *Supervisor) Latest() map[string][]Reading {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	latest := make(map[string][]Reading, len(s.latest))
+	for id, rs := range s.latest {
+		latest[id] = append([]Reading(nil), rs...)
+	}
+	return latest
+}
@@ -0,0 +1,171 @@
+package temperedgo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordSink keeps every reading written to it, failing instead once
+// failAfter have been written if failAfter is positive.
+type recordSink struct {
+	lock      sync.Mutex
+	readings  []Reading
+	failAfter int
+	closed    bool
+}
+
+func (s *recordSink) Write(ctx context.Context, r Reading) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.failAfter > 0 && len(s.readings) >= s.failAfter {
+		return errors.New("sink full")
+	}
+	s.readings = append(s.readings, r)
+	return nil
+}
+
+func (s *recordSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.closed = true
+	return nil
+}
+
+// waitFor polls cond until it holds, failing the test after a few seconds.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestNewSupervisorValidates(t *testing.T) {
+	if _, err := NewSupervisor(&Tempered{}, DeviceFilter{}, 0); err == nil {
+		t.Error("NewSupervisor with a zero interval succeeded, want an error")
+	}
+}
+
+func TestSupervisor(t *testing.T) {
+	fb := &FakeBackend{Devices: []*FakeDevice{
+		{Path: "/dev/fake0", Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_TEMPERATURE, Temperature: 20}}},
+		{Path: "/dev/fake1", Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_TEMPERATURE, Temperature: 21}}},
+	}}
+	tm := &Tempered{Backend: fb}
+	if err := tm.Init(); err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewSupervisor(tm, DeviceFilter{}, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	good, full := &recordSink{}, &recordSink{failAfter: 1}
+	var sinkErrs int
+	var sinkErrsLock sync.Mutex
+	s.Sinks = []Sink{good, full}
+	s.OnSinkError = func(error) {
+		sinkErrsLock.Lock()
+		sinkErrs++
+		sinkErrsLock.Unlock()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+
+	waitFor(t, "both devices to be read", func() bool { return len(s.Latest()) == 2 })
+
+	// An unplugged device is dropped, and picked up again when it returns.
+	fb.Lock()
+	unplugged := fb.Devices[1]
+	fb.Devices = fb.Devices[:1]
+	fb.Unlock()
+	waitFor(t, "the unplugged device to be dropped", func() bool { return len(s.Latest()) == 1 })
+	fb.Lock()
+	fb.Devices = append(fb.Devices, unplugged)
+	fb.Unlock()
+	waitFor(t, "the device to be picked up again", func() bool { return len(s.Latest()) == 2 })
+
+	// A device that keeps failing to read is closed and dropped.
+	fb.Lock()
+	fb.Devices[0].FailUpdate = true
+	fb.Unlock()
+	waitFor(t, "the failing device to be dropped", func() bool {
+		_, ok := s.Latest()["/dev/fake0"]
+		return !ok
+	})
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run = %v, want context.Canceled", err)
+	}
+
+	stats := s.Stats()
+	if stats.Produced == 0 {
+		t.Error("Stats().Produced = 0 after reading")
+	}
+	if got := stats.Sinks[0]; got.Delivered != stats.Produced || got.Dropped != 0 {
+		t.Errorf("good sink stats = %+v, want all %d delivered", got, stats.Produced)
+	}
+	if got := stats.Sinks[1]; got.Delivered != 1 || got.Delivered+got.Dropped != stats.Produced {
+		t.Errorf("full sink stats = %+v, want 1 delivered and the rest of %d dropped", got, stats.Produced)
+	}
+	sinkErrsLock.Lock()
+	if sinkErrs == 0 {
+		t.Error("OnSinkError was never called for the full sink")
+	}
+	sinkErrsLock.Unlock()
+
+	if !good.closed || !full.closed {
+		t.Error("shutdown did not close the sinks")
+	}
+	if n := fb.OpenHandles(); n != 0 {
+		t.Errorf("OpenHandles() = %d after shutdown, want 0", n)
+	}
+	if _, err := tm.DeviceList(); !errors.Is(err, ERR_NOT_INITED) {
+		t.Errorf("DeviceList after shutdown = %v, want ERR_NOT_INITED", err)
+	}
+}
+
+func TestSupervisorCloseStopsRun(t *testing.T) {
+	fb := &FakeBackend{Devices: []*FakeDevice{
+		{Path: "/dev/fake0", Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_TEMPERATURE}}},
+	}}
+	tm := &Tempered{Backend: fb}
+	if err := tm.Init(); err != nil {
+		t.Fatal(err)
+	}
+	s, err := NewSupervisor(tm, DeviceFilter{}, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- s.Run(context.Background()) }()
+	waitFor(t, "the device to be read", func() bool { return len(s.Latest()) == 1 })
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Run after Close = %v, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run kept going after Close")
+	}
+	if err := s.Close(); err != nil {
+		t.Errorf("second Close = %v, want nil", err)
+	}
+	if n := fb.OpenHandles(); n != 0 {
+		t.Errorf("OpenHandles() = %d after Close, want 0", n)
+	}
+}
@@ -1,45 +1,200 @@
 package temperedgo
 
-// #cgo LDFLAGS: -ltempered -lhidapi-hidraw
-// #include <tempered.h>
-// #include <stdlib.h>
-import "C"
-
 import (
+	"context"
 	"errors"
-	"unsafe"
+	"fmt"
+	"maps"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	ERR_NOT_INITED              = errors.New(`tempered: not initialised`)
+	ERR_NOT_OPEN                = errors.New(`tempered: device not open`)
+	ERR_FAILED_RETRIEVE         = errors.New(`tempered: failed to retrieve sensor reading`)
+	ERR_FAILED_UPDATE           = errors.New(`tempered: failed to update sensors`)
+	ERR_NO_DEVICE_FOUND         = errors.New(`tempered: no matching device found`)
+	ERR_IMPLAUSIBLE             = errors.New(`tempered: implausible sensor reading`)
+	ERR_NO_SENSORS              = errors.New(`tempered: device reports no sensors`)
+	ERR_UNSUPPORTED_MEASUREMENT = errors.New(`tempered: sensor does not support the required measurement`)
+	ERR_SENSOR_OUT_OF_RANGE     = errors.New(`tempered: sensor number out of range`)
+	ERR_TIMEOUT                 = errors.New(`tempered: timed out`)
+	ERR_ROLE_NOT_FOUND          = errors.New(`tempered: no sensor with the requested role`)
+	ERR_NOT_SUPPORTED           = errors.New(`tempered: operation not supported by the backend`)
+	ERR_ENUMERATE_FAILED        = errors.New(`tempered: device enumeration failed`)
+	ERR_STALE_READING           = errors.New(`tempered: no sufficiently fresh reading`)
+	ERR_SENSOR_FROZEN           = errors.New(`tempered: sensor value not changing`)
+	ERR_CIRCUIT_OPEN            = errors.New(`tempered: device disabled after repeated failures`)
+	ERR_PARTIAL_UPDATE          = errors.New(`tempered: update failed but some sensor values are readable`)
+	ERR_UNSTABLE_READING        = errors.New(`tempered: consecutive readings disagree`)
+	ERR_INIT_FAILED             = errors.New(`tempered: failed to initialise library`)
+	ERR_EXIT_FAILED             = errors.New(`tempered: failed to shut down library`)
+	ERR_OPEN_FAILED             = errors.New(`tempered: failed to open device`)
+	ERR_COMMAND_FAILED          = errors.New(`tempered: failed to send command`)
 )
 
+// libLock guards the native library's init state. Device operations hold it
+// for reading so that Exit cannot tear the library down underneath them.
+// libRefs counts, per backend, Init calls not yet matched by Exit across
+// every Tempered using it; a backend is inited on the first and exited on
+// the last.
 var (
-	ERR_NOT_INITED      = errors.New(`tempered: not initialised`)
-	ERR_NOT_OPEN        = errors.New(`tempered: device not open`)
-	ERR_FAILED_RETRIEVE = errors.New(`tempered: failed to retrieve sensor reading`)
-	ERR_FAILED_UPDATE   = errors.New(`tempered: failed to update sensors`)
+	libLock sync.RWMutex
+	libRefs = map[Backend]int{}
 )
 
+// deviceLockInit guards giving a device its lock. It is only ever held for
+// that, never across a native call.
+var deviceLockInit sync.Mutex
+
+// mutex returns the device's lock, creating it on first use for devices
+// not made by DeviceList.
+func (t *TemperedDevice) mutex() *sync.Mutex {
+	deviceLockInit.Lock()
+	defer deviceLockInit.Unlock()
+
+	if t.lock == nil {
+		t.lock = new(sync.Mutex)
+	}
+	return t.lock
+}
+
+// lockDevice locks the device and waits for any native call abandoned by a
+// context on it to finish, so that no two native calls on the handle ever
+// overlap. The caller must hold libLock.
+func (t *TemperedDevice) lockDevice() {
+	t.mutex().Lock()
+	if t.dev != nil {
+		waitNative(t.dev)
+	}
+}
+
+func (t *TemperedDevice) unlockDevice() {
+	t.mutex().Unlock()
+}
+
+// acquireLib read-locks libLock if b is inited, and otherwise returns
+// ERR_NOT_INITED.
+func acquireLib(b Backend) error {
+	libLock.RLock()
+	if libRefs[b] == 0 {
+		libLock.RUnlock()
+		return ERR_NOT_INITED
+	}
+	return nil
+}
+
 type Tempered struct {
-	inited bool
+	// refs counts this Tempered's Init calls not yet matched by Exit.
+	refs int
+
+	// ErrorMode controls how bulk operations such as Scan, OpenAll and
+	// ReadAllFlat handle a device that fails.
+	ErrorMode ErrorMode
+
+	// Backend is the native library implementation used. The default of nil
+	// means the cgo binding to libtempered, or DefaultFake when built
+	// without cgo.
+	Backend Backend
+
+	// CalibrationStore, if set, supplies the calibration offsets applied to
+	// each device as it is opened.
+	CalibrationStore CalibrationStore
+
+	// lock serialises Init, Exit and DeviceList.
+	lock sync.Mutex
+
+	trackLock sync.Mutex
+	tracked   []*TemperedDevice
+
+	cacheLock    sync.Mutex
+	cacheDevices []TemperedDevice
+	cacheTime    time.Time
 }
 
 type TemperedDevice struct {
-	dev unsafe.Pointer
+	// lock serialises operations on the device. DeviceList gives each
+	// device its own, shared by copies of it; devices made any other way
+	// get theirs on first use.
+	lock *sync.Mutex
+
+	dev        DeviceHandle
+	warmUntil  time.Time
+	lastUpdate time.Time
+	lastRead   []Reading
+	readTime   time.Time
+	sensors    []*TemperedSensor
+	latency    time.Duration
+	bg         *backgroundPoll
+
+	backend  Backend
+	calStore CalibrationStore
+	cal      calibration
 
 	Path            string
 	TypeName        string
 	VendorId        uint
 	ProductId       uint
 	InterfaceNumber int
+
+	// WarmUp delays the first update after Open until the device has been
+	// open this long, for sensors that report garbage straight after being
+	// opened. The default of zero means no delay.
+	WarmUp time.Duration
+
+	// LenientUpdate makes a failed update that still leaves some sensor
+	// values readable return ERR_PARTIAL_UPDATE instead of
+	// ERR_FAILED_UPDATE, and lets ReadAll return those values. They may
+	// predate the failed update.
+	LenientUpdate bool
+
+	// ClampHumidity limits humidity readings to 0-100%RH, after quirks and
+	// calibration, for consumers that can't cope with the slight overshoot
+	// some sensors report in saturated air. That overshoot is information
+	// lost by clamping; RawHumidity still returns it.
+	ClampHumidity bool
+
+	// ReadDelay is waited between updating the device and fetching its
+	// values in ReadAll, FreshReading and SnapshotAll, for models whose
+	// values take a moment to settle after an update. The default is zero.
+	ReadDelay time.Duration
+
+	// CoalesceUpdates makes concurrent Update calls on the same open device
+	// share one native update, all returning its result, instead of each
+	// reading the hardware.
+	CoalesceUpdates bool
 }
 
 type TemperedSensorType int
 
+// IsType reports whether st includes every measurement in t; a sensor
+// measuring temperature and humidity IsType(TEMPERED_SENSOR_TYPE_TEMPERATURE).
+// Nothing IsType(TEMPERED_SENSOR_TYPE_NONE): an empty t asks for no
+// measurement, so it is never a match.
 func (st TemperedSensorType) IsType(t TemperedSensorType) bool {
-	return st&t == t
+	return t != TEMPERED_SENSOR_TYPE_NONE && st&t == t
+}
+
+// IsExactly reports whether st is exactly t, so a sensor measuring
+// temperature and humidity is not IsExactly(TEMPERED_SENSOR_TYPE_TEMPERATURE).
+func (st TemperedSensorType) IsExactly(t TemperedSensorType) bool {
+	return st == t
 }
 
+// These match libtempered's enum tempered_sensor_type.
 const (
-	TEMPERED_SENSOR_TYPE_TEMPERATURE = C.TEMPERED_SENSOR_TYPE_TEMPERATURE
-	TEMPERED_SENSOR_TYPE_HUMIDITY    = C.TEMPERED_SENSOR_TYPE_HUMIDITY
+	// TEMPERED_SENSOR_TYPE_NONE is the empty mask, of a sensor that makes no
+	// known measurement.
+	TEMPERED_SENSOR_TYPE_NONE        = 0
+	TEMPERED_SENSOR_TYPE_TEMPERATURE = 1
+	TEMPERED_SENSOR_TYPE_HUMIDITY    = 2
+
+	// TEMPERED_SENSOR_TYPE_ALL combines every known measurement, so
+	// IsType(TEMPERED_SENSOR_TYPE_ALL) means a sensor measures everything.
+	TEMPERED_SENSOR_TYPE_ALL = TEMPERED_SENSOR_TYPE_TEMPERATURE | TEMPERED_SENSOR_TYPE_HUMIDITY
 )
 
 type TemperedSensor struct {
@@ -47,192 +202,619 @@ type TemperedSensor struct {
 	sensorNum int
 
 	TypeMask TemperedSensorType
+	Role     SensorRole
+	Label    string
+
+	// Tags are arbitrary metadata, such as a location, copied into every
+	// Reading from the sensor and exported as labels or tags.
+	Tags map[string]string
+
+	// CondensationMargin is how close, in degrees Celsius, the temperature
+	// may come to the dew point before CondensationRisk reports a risk. Zero
+	// means DEFAULT_CONDENSATION_MARGIN.
+	CondensationMargin float64
+
+	// Transform, if set, is applied to every value read from the sensor,
+	// after quirks and calibration, including by the device's ReadAll.
+	Transform TransformFunc
 }
 
-func (ts *TemperedSensor) Temperature() (float64, error) {
-	return ts.device.Temperature(ts.sensorNum)
+// TransformFunc adjusts a value read from a sensor. t is
+// TEMPERED_SENSOR_TYPE_TEMPERATURE or TEMPERED_SENSOR_TYPE_HUMIDITY, saying
+// which measurement val is.
+type TransformFunc func(t TemperedSensorType, val float64) float64
+
+func (ts *TemperedSensor) transform(t TemperedSensorType, val float64) float64 {
+	if ts.Transform == nil {
+		return val
+	}
+	return ts.Transform(t, val)
+}
+
+func (ts *TemperedSensor) Temperature() (Celsius, error) {
+	val, err := ts.device.Temperature(ts.sensorNum)
+	if err != nil {
+		return 0, err
+	}
+	return Celsius(ts.transform(TEMPERED_SENSOR_TYPE_TEMPERATURE, val.Float64())), nil
 }
 
 func (ts *TemperedSensor) Humidity() (float64, error) {
-	return ts.device.Humidity(ts.sensorNum)
+	val, err := ts.device.Humidity(ts.sensorNum)
+	if err != nil {
+		return 0, err
+	}
+	return ts.transform(TEMPERED_SENSOR_TYPE_HUMIDITY, val), nil
+}
+
+// TemperatureRawAndCorrected returns the temperature both as the device
+// reported it and after quirks, calibration and Transform, from the same
+// cached value.
+func (ts *TemperedSensor) TemperatureRawAndCorrected() (raw, corrected float64, err error) {
+	raw, corrected, err = ts.device.temperature(context.Background(), ts.sensorNum)
+	if err != nil {
+		return 0, 0, err
+	}
+	return raw, ts.transform(TEMPERED_SENSOR_TYPE_TEMPERATURE, corrected), nil
 }
 
 func (t *TemperedDevice) Open() error {
+	if err := acquireLib(t.backendOrDefault()); err != nil {
+		return t.deviceError(err)
+	}
+	defer libLock.RUnlock()
+	t.lockDevice()
+	defer t.unlockDevice()
+
 	if t.dev != nil {
 		return nil
 	}
 
-	devList := C.struct_tempered_device_list{
-		next:             nil,
-		path:             C.CString(t.Path),
-		type_name:        C.CString(t.TypeName),
-		vendor_id:        C.ushort(t.VendorId),
-		product_id:       C.ushort(t.ProductId),
-		interface_number: C.int(t.InterfaceNumber),
-	}
-	defer func() {
-		C.free(unsafe.Pointer(devList.path))
-		C.free(unsafe.Pointer(devList.type_name))
-	}()
-
-	var errCstr *C.char
-	devRet := C.tempered_open(&devList, &errCstr)
-	if devRet == nil {
-		err := errors.New(C.GoString(errCstr))
-		C.free(unsafe.Pointer(errCstr))
-		return err
+	dev, err := t.backendOrDefault().Open(t)
+	if err != nil {
+		return t.deviceError(err)
 	}
 
-	t.dev = unsafe.Pointer(devRet)
-
+	t.opened(dev)
 	return nil
 }
 
-func (t *TemperedDevice) getParamDev() *C.struct_tempered_device_ {
-	return (*C.struct_tempered_device_)(unsafe.Pointer(t.dev))
+// opened attaches a freshly opened native handle to the device.
+func (t *TemperedDevice) opened(dev DeviceHandle) {
+	t.dev = dev
+	if t.WarmUp > 0 {
+		t.warmUntil = time.Now().Add(t.WarmUp)
+	}
+	if t.calStore != nil {
+		if tempOffset, humOffset, ok := t.calStore.Load(t.ID()); ok {
+			t.SetCalibration(tempOffset, humOffset)
+		}
+	}
+}
+
+func (t *TemperedDevice) waitWarmUp() {
+	t.mutex().Lock()
+	until := t.warmUntil
+	t.mutex().Unlock()
+
+	if !until.IsZero() {
+		time.Sleep(time.Until(until))
+	}
+}
+
+// ID identifies the device across enumerations.
+func (t *TemperedDevice) ID() string {
+	return t.Path
+}
+
+func (t *TemperedDevice) deviceError(err error) error {
+	return fmt.Errorf("%s: %w", t.Path, err)
+}
+
+func (t *TemperedDevice) sensorError(sensorNum int, err error) error {
+	return fmt.Errorf("%s sensor %d: %w", t.Path, sensorNum, err)
 }
 
 func (t *TemperedDevice) SensorCount() (int, error) {
+	if err := acquireLib(t.backendOrDefault()); err != nil {
+		return 0, err
+	}
+	defer libLock.RUnlock()
+	t.lockDevice()
+	defer t.unlockDevice()
+
 	if t.dev == nil {
 		return 0, ERR_NOT_OPEN
 	}
 
-	sCount := int(C.tempered_get_sensor_count(t.getParamDev()))
+	sCount := t.dev.SensorCount()
 
 	return sCount, nil
 }
 
 func (t *TemperedDevice) Update() error {
+	return t.UpdateContext(context.Background())
+}
+
+// UpdateContext is Update, returning ctx.Err() if ctx is done before the
+// native update finishes. The native call cannot be interrupted and runs to
+// completion in the background; Close and Exit wait for it before freeing
+// the device, so an abandoned update never touches freed memory.
+func (t *TemperedDevice) UpdateContext(ctx context.Context) error {
+	t.waitWarmUp()
+
+	if err := acquireLib(t.backendOrDefault()); err != nil {
+		return t.deviceError(err)
+	}
+	defer libLock.RUnlock()
+
+	if t.CoalesceUpdates {
+		return t.coalescedUpdate(ctx)
+	}
+	t.lockDevice()
+	defer t.unlockDevice()
+	return t.updateContext(ctx)
+}
+
+// update reads the sensors into the native cache. A device reporting no sensors
+// fails with ERR_NO_SENSORS without being asked to read, as Sensors and
+// ReadAll do, rather than surfacing whatever ERR_FAILED_UPDATE the native read
+// of nothing would produce; an empty device is broken, not transiently failing.
+func (t *TemperedDevice) update() error {
+	return t.updateContext(context.Background())
+}
+
+func (t *TemperedDevice) updateContext(ctx context.Context) error {
 	if t.dev == nil {
-		return ERR_NOT_OPEN
+		return t.deviceError(ERR_NOT_OPEN)
+	}
+	if t.dev.SensorCount() <= 0 {
+		t.sensors = nil
+		return t.deviceError(ERR_NO_SENSORS)
 	}
 
-	didWork := C.tempered_read_sensors(t.getParamDev())
+	start := time.Now()
+	didWork, err := runNative(ctx, t.dev, t.dev.ReadSensors)
+	if err != nil {
+		err = t.deviceError(err)
+		t.observe(OP_UPDATE, -1, start, 0, err)
+		return err
+	}
 
 	if !didWork {
-		return ERR_FAILED_UPDATE
+		err := t.deviceError(&TemperedError{Op: OP_UPDATE, Err: ERR_FAILED_UPDATE})
+		if t.LenientUpdate && t.anyValueReadable() {
+			err = t.deviceError(&TemperedError{Op: OP_UPDATE, Err: ERR_PARTIAL_UPDATE})
+		}
+		t.observe(OP_UPDATE, -1, start, 0, err)
+		return err
+	}
+	t.observe(OP_UPDATE, -1, start, 0, nil)
+	t.lastUpdate = time.Now()
+	if t.sensors != nil && len(t.sensors) != t.dev.SensorCount() {
+		t.sensors = nil
 	}
 	return nil
 }
 
+func (t *TemperedDevice) anyValueReadable() bool {
+	sCount := t.dev.SensorCount()
+	if sCount <= 0 {
+		return false
+	}
+	for _, v := range readSensorValues(t.dev, sCount) {
+		if v.temperatureOk || v.humidityOk {
+			return true
+		}
+	}
+	return false
+}
+
+// Age returns how long ago the device was last successfully updated, which is
+// how old the values that Temperature and Humidity return are. A device that
+// has not been updated since it was opened is infinitely old.
+func (t *TemperedDevice) Age() time.Duration {
+	t.mutex().Lock()
+	defer t.mutex().Unlock()
+
+	if t.lastUpdate.IsZero() {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Since(t.lastUpdate)
+}
+
 func (t *TemperedDevice) Sensors() ([]*TemperedSensor, error) {
+	if err := acquireLib(t.backendOrDefault()); err != nil {
+		return nil, err
+	}
+	defer libLock.RUnlock()
+	t.lockDevice()
+	defer t.unlockDevice()
+
 	if t.dev == nil {
 		return nil, ERR_NOT_OPEN
 	}
 
-	tsList := []*TemperedSensor{}
-	sCount := int(C.tempered_get_sensor_count(t.getParamDev()))
-	for n := 0; n < sCount; n++ {
-		ts := new(TemperedSensor)
-		ts.device = t
-		ts.sensorNum = n
-		ts.TypeMask = TemperedSensorType(C.tempered_get_sensor_type(t.getParamDev(), C.int(n)))
-		tsList = append(tsList, ts)
+	sCount := t.dev.SensorCount()
+	if sCount <= 0 {
+		t.sensors = nil
+		return nil, t.deviceError(ERR_NO_SENSORS)
+	}
+
+	// The sensor list is cached so that fields set on the sensors, such as
+	// Label, survive repeated calls. If the device has renumbered its sensors
+	// the cache no longer lines up and is rebuilt.
+	if len(t.sensors) != sCount {
+		tsList := []*TemperedSensor{}
+		for n := 0; n < sCount; n++ {
+			ts := new(TemperedSensor)
+			ts.device = t
+			ts.sensorNum = n
+			ts.TypeMask = t.dev.SensorType(n)
+			ts.Role = sensorRole(t.TypeName, n)
+			tsList = append(tsList, ts)
+		}
+		t.sensors = tsList
 	}
 
+	return append([]*TemperedSensor(nil), t.sensors...), nil
+}
+
+// SortedSensors is Sensors ordered by sensor number, so that the order is
+// stable from one call to the next.
+func (t *TemperedDevice) SortedSensors() ([]*TemperedSensor, error) {
+	tsList, err := t.Sensors()
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(tsList, func(i, j int) bool {
+		return tsList[i].sensorNum < tsList[j].sensorNum
+	})
 	return tsList, nil
 }
 
-func (t *TemperedDevice) Temperature(sensorNum int) (float64, error) {
+// Temperature returns the temperature in degrees Celsius cached by the last
+// Update. libtempered stores it as a C float; widening that to float64 is
+// exact, so sub-zero values keep their sign and magnitude and no rounding is
+// introduced. Values with no exact single-precision representation (such as
+// 21.3) do show the float32 error when printed at full float64 precision.
+func (t *TemperedDevice) Temperature(sensorNum int) (Celsius, error) {
+	return t.TemperatureContext(context.Background(), sensorNum)
+}
+
+// TemperatureContext is Temperature, returning ctx.Err() if ctx is done
+// before the native call returns, which carries on in the background as in
+// UpdateContext.
+func (t *TemperedDevice) TemperatureContext(ctx context.Context, sensorNum int) (Celsius, error) {
+	_, val, err := t.temperature(ctx, sensorNum)
+	return Celsius(val), err
+}
+
+// temperature returns both the native temperature and the value after quirks
+// and calibration.
+func (t *TemperedDevice) temperature(ctx context.Context, sensorNum int) (raw, corrected float64, err error) {
+	if err := acquireLib(t.backendOrDefault()); err != nil {
+		return 0, 0, t.sensorError(sensorNum, err)
+	}
+	defer libLock.RUnlock()
+	t.lockDevice()
+	defer t.unlockDevice()
+
 	if t.dev == nil {
-		return 0, ERR_NOT_OPEN
+		return 0, 0, t.sensorError(sensorNum, ERR_NOT_OPEN)
+	}
+	if sensorNum < 0 || sensorNum >= t.dev.SensorCount() {
+		return 0, 0, t.sensorError(sensorNum, ERR_SENSOR_OUT_OF_RANGE)
 	}
 
-	var cFloat C.float
-	retrOk := C.tempered_get_temperature(t.getParamDev(), C.int(sensorNum), &cFloat)
+	start := time.Now()
+	dev := t.dev
+	raw, retrOk, err := runNative2(ctx, dev, func() (float64, bool) {
+		return dev.Temperature(sensorNum)
+	})
+	if err != nil {
+		err = t.sensorError(sensorNum, err)
+		t.observe(OP_TEMPERATURE, sensorNum, start, 0, err)
+		return 0, 0, err
+	}
 	if !retrOk {
-		return 0, ERR_FAILED_RETRIEVE
+		err := t.sensorError(sensorNum, &TemperedError{Op: OP_TEMPERATURE, Err: ERR_FAILED_RETRIEVE})
+		t.observe(OP_TEMPERATURE, sensorNum, start, 0, err)
+		return 0, 0, err
 	}
+	corrected = t.correctTemperature(sensorNum, raw, t.calibration())
+	t.observe(OP_TEMPERATURE, sensorNum, start, corrected, nil)
 
-	return float64(cFloat), nil
+	return raw, corrected, nil
+}
+
+// TemperatureFast is Temperature without the open check. It is unsafe: the
+// caller must ensure the device is open and sensorNum is valid, otherwise it
+// dereferences a nil device handle.
+func (t *TemperedDevice) TemperatureFast(sensorNum int) (Celsius, error) {
+	val, ok := t.dev.Temperature(sensorNum)
+	if !ok {
+		return 0, &TemperedError{Op: OP_TEMPERATURE, Err: ERR_FAILED_RETRIEVE}
+	}
+	return Celsius(t.correctTemperature(sensorNum, val, t.calibration())), nil
 }
 
 func (t *TemperedDevice) Humidity(sensorNum int) (float64, error) {
+	return t.HumidityContext(context.Background(), sensorNum)
+}
+
+// HumidityContext is Humidity, returning ctx.Err() if ctx is done before the
+// native call returns, which carries on in the background as in
+// UpdateContext.
+func (t *TemperedDevice) HumidityContext(ctx context.Context, sensorNum int) (float64, error) {
+	return t.humidity(ctx, sensorNum, false)
+}
+
+// RawHumidity is Humidity as the device reported it, before quirks,
+// calibration and ClampHumidity are applied.
+func (t *TemperedDevice) RawHumidity(sensorNum int) (float64, error) {
+	return t.humidity(context.Background(), sensorNum, true)
+}
+
+func (t *TemperedDevice) humidity(ctx context.Context, sensorNum int, raw bool) (float64, error) {
+	if err := acquireLib(t.backendOrDefault()); err != nil {
+		return 0, t.sensorError(sensorNum, err)
+	}
+	defer libLock.RUnlock()
+	t.lockDevice()
+	defer t.unlockDevice()
+
 	if t.dev == nil {
-		return 0, ERR_NOT_OPEN
+		return 0, t.sensorError(sensorNum, ERR_NOT_OPEN)
+	}
+	if sensorNum < 0 || sensorNum >= t.dev.SensorCount() {
+		return 0, t.sensorError(sensorNum, ERR_SENSOR_OUT_OF_RANGE)
 	}
 
-	var cFloat C.float
-	retrOk := C.tempered_get_humidity(t.getParamDev(), C.int(sensorNum), &cFloat)
+	start := time.Now()
+	dev := t.dev
+	val, retrOk, err := runNative2(ctx, dev, func() (float64, bool) {
+		return dev.Humidity(sensorNum)
+	})
+	if err != nil {
+		err = t.sensorError(sensorNum, err)
+		t.observe(OP_HUMIDITY, sensorNum, start, 0, err)
+		return 0, err
+	}
 	if !retrOk {
-		return 0, ERR_FAILED_RETRIEVE
+		err := t.sensorError(sensorNum, &TemperedError{Op: OP_HUMIDITY, Err: ERR_FAILED_RETRIEVE})
+		t.observe(OP_HUMIDITY, sensorNum, start, 0, err)
+		return 0, err
 	}
+	if !raw {
+		val = t.correctHumidity(sensorNum, val, t.calibration())
+	}
+	t.observe(OP_HUMIDITY, sensorNum, start, val, nil)
 
-	return float64(cFloat), nil
+	return val, nil
 }
 
-func (t *TemperedDevice) Close() error {
+// ReadAll updates the device and reads every sensor, returning the readings
+// in sensor number order. The cgo backend fetches
+// the sensor values in one batched native call, so a full device read costs
+// three cgo calls (update, sensor count, batch) instead of the 2+3N needed to
+// go through Update, Sensors and per-sensor Temperature/Humidity for N dual
+// sensors.
+func (t *TemperedDevice) ReadAll() ([]Reading, error) {
+	t.waitWarmUp()
+
+	if err := acquireLib(t.backendOrDefault()); err != nil {
+		return nil, t.deviceError(err)
+	}
+	defer libLock.RUnlock()
+	t.lockDevice()
+	defer t.unlockDevice()
+	return t.readAll()
+}
+
+// readAll is ReadAll for a caller holding libLock and the device lock. A
+// successful read is kept for ReadCached.
+func (t *TemperedDevice) readAll() ([]Reading, error) {
+	if err := t.update(); err != nil && !errors.Is(err, ERR_PARTIAL_UPDATE) {
+		return nil, err
+	}
+
+	time.Sleep(t.ReadDelay)
+	now := time.Now()
+	rs, err := t.readCached(now)
+	if err != nil {
+		return nil, err
+	}
+	t.lastRead = cloneReadings(rs)
+	t.readTime = now
+	return rs, nil
+}
+
+// Read is ReadAll: it updates the device and returns a Reading per sensor,
+// with Temperature and Humidity set only where the sensor's type advertises
+// them.
+func (t *TemperedDevice) Read() ([]Reading, error) {
+	return t.ReadAll()
+}
+
+// readCached builds readings, stamped with at, from the values cached by the
+// last update. The caller must hold libLock.
+func (t *TemperedDevice) readCached(at time.Time) ([]Reading, error) {
 	if t.dev == nil {
-		return nil
+		return nil, t.deviceError(ERR_NOT_OPEN)
 	}
 
-	C.tempered_close(t.getParamDev())
-	return nil
+	sCount := t.dev.SensorCount()
+	if sCount <= 0 {
+		return nil, t.deviceError(ERR_NO_SENSORS)
+	}
+
+	start := time.Now()
+	values := readSensorValues(t.dev, sCount)
+	t.observe(OP_READ_ALL, -1, start, 0, nil)
+
+	cal := t.calibration()
+	rs := make([]Reading, 0, sCount)
+	for n, v := range values {
+		var ts *TemperedSensor
+		if len(t.sensors) == sCount {
+			ts = t.sensors[n]
+		}
+		r := Reading{
+			Time:      at,
+			DeviceID:  t.ID(),
+			SensorNum: n,
+			Type:      v.sensorType,
+			Role:      sensorRole(t.TypeName, n),
+		}
+		if ts != nil {
+			r.Label = ts.Label
+			r.Tags = maps.Clone(ts.Tags)
+		}
+		if r.Type.IsType(TEMPERED_SENSOR_TYPE_TEMPERATURE) {
+			if !v.temperatureOk {
+				return nil, t.sensorError(n, &TemperedError{Op: OP_TEMPERATURE, Err: ERR_FAILED_RETRIEVE})
+			}
+			val := t.correctTemperature(n, v.temperature, cal)
+			if ts != nil {
+				val = ts.transform(TEMPERED_SENSOR_TYPE_TEMPERATURE, val)
+			}
+			r.Temperature = &val
+		}
+		if r.Type.IsType(TEMPERED_SENSOR_TYPE_HUMIDITY) {
+			if !v.humidityOk {
+				return nil, t.sensorError(n, &TemperedError{Op: OP_HUMIDITY, Err: ERR_FAILED_RETRIEVE})
+			}
+			val := t.correctHumidity(n, v.humidity, cal)
+			if ts != nil {
+				val = ts.transform(TEMPERED_SENSOR_TYPE_HUMIDITY, val)
+			}
+			r.Humidity = &val
+		}
+		applyAccuracy(t.TypeName, &r)
+		rs = append(rs, r)
+	}
+
+	return rs, nil
 }
 
-func (t *Tempered) Init() error {
-	if t.inited {
+func (t *TemperedDevice) Close() error {
+	// The lock is only held to detach the handle: waiting for abandoned
+	// native calls under it would block CloseTimeout.
+	t.mutex().Lock()
+	dev := t.detach()
+	t.mutex().Unlock()
+	if dev == nil {
 		return nil
 	}
 
-	var errCstr *C.char
-	ret := C.tempered_init(&errCstr)
-	if !ret {
-		err := errors.New(C.GoString(errCstr))
-		C.free(unsafe.Pointer(errCstr))
+	libLock.RLock()
+	defer libLock.RUnlock()
+
+	waitNative(dev)
+	dev.Close()
+	return nil
+}
+
+// detach forgets the native handle and everything cached from it, returning
+// the handle for the caller to close.
+func (t *TemperedDevice) detach() DeviceHandle {
+	dev := t.dev
+	t.dev = nil
+	t.lastUpdate = time.Time{}
+	t.lastRead = nil
+	t.readTime = time.Time{}
+	t.sensors = nil
+	return dev
+}
+
+// Reset closes and reopens the device in place, for recovering a device that
+// has stopped responding. The cached sensor list is discarded, so sensors
+// must be fetched again afterwards. If the reopen fails the device is left
+// closed.
+func (t *TemperedDevice) Reset() error {
+	if err := t.Close(); err != nil {
 		return err
 	}
+	return t.Open()
+}
 
-	t.inited = true
+// Init inits the backend if it isn't already. Calls are counted per
+// backend, so code paths that each Init and Exit independently can share
+// it: it stays inited until every Init has been matched by an Exit. Each
+// backend is counted separately, so a Tempered using a different backend
+// always inits its own.
+func (t *Tempered) Init() error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	libLock.Lock()
+	defer libLock.Unlock()
+
+	b := t.backendOrDefault()
+	if libRefs[b] == 0 {
+		if err := b.Init(); err != nil {
+			return err
+		}
+	}
+
+	t.refs++
+	libRefs[b]++
 	return nil
 }
 
+// DeviceList enumerates the attached devices. The native enumeration is
+// all-or-nothing: libtempered builds the whole list before returning it and
+// discards it on any failure, so a partial list cannot be recovered. Such
+// failures wrap ERR_ENUMERATE_FAILED together with libtempered's message.
 func (t *Tempered) DeviceList() ([]TemperedDevice, error) {
-	if !t.inited {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	libLock.RLock()
+	defer libLock.RUnlock()
+
+	if t.refs == 0 || libRefs[t.backendOrDefault()] == 0 {
 		return nil, ERR_NOT_INITED
 	}
 
-	var errCstr *C.char
-	var cDevices *C.struct_tempered_device_list
-	cDevices = C.tempered_enumerate(&errCstr)
-	if cDevices == nil {
-		err := errors.New(C.GoString(errCstr))
-		C.free(unsafe.Pointer(errCstr))
+	tds, err := t.backendOrDefault().Enumerate()
+	if err != nil {
 		return nil, err
 	}
-	defer func() {
-		C.tempered_free_device_list(cDevices)
-	}()
-
-	tds := []TemperedDevice{}
-	for dev := cDevices; dev != nil; dev = dev.next {
-		td := TemperedDevice{
-			Path:            C.GoString(dev.path),
-			TypeName:        C.GoString(dev.type_name),
-			VendorId:        uint(dev.vendor_id),
-			ProductId:       uint(dev.product_id),
-			InterfaceNumber: int(dev.interface_number),
-		}
-		tds = append(tds, td)
+	for n := range tds {
+		tds[n].lock = new(sync.Mutex)
+		tds[n].backend = t.Backend
+		tds[n].calStore = t.CalibrationStore
 	}
-
 	return tds, nil
 }
 
+// Exit undoes one Init by this Tempered, exiting its backend when no Init of
+// that backend remains outstanding. Extra calls do nothing.
 func (t *Tempered) Exit() error {
-	if !t.inited {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	libLock.Lock()
+	defer libLock.Unlock()
+
+	if t.refs == 0 {
 		return nil
 	}
 
-	var errCstr *C.char
-	ret := C.tempered_exit(&errCstr)
-	if !ret {
-		err := errors.New(C.GoString(errCstr))
-		C.free(unsafe.Pointer(errCstr))
-		return err
+	b := t.backendOrDefault()
+	if libRefs[b] == 1 {
+		waitAllNative()
+		if err := b.Exit(); err != nil {
+			return err
+		}
 	}
 
-	t.inited = false
+	t.refs--
+	if libRefs[b]--; libRefs[b] == 0 {
+		delete(libRefs, b)
+	}
 	return nil
 }
 
@@ -0,0 +1,444 @@
+package temperedgo
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingBackend is a FakeBackend that counts Init and Exit calls.
+type countingBackend struct {
+	*FakeBackend
+	inits, exits int
+}
+
+func (b *countingBackend) Init() error {
+	b.inits++
+	return b.FakeBackend.Init()
+}
+
+func (b *countingBackend) Exit() error {
+	b.exits++
+	return b.FakeBackend.Exit()
+}
+
+func TestInitExitRefCount(t *testing.T) {
+	b := &countingBackend{FakeBackend: &FakeBackend{}}
+	tm := &Tempered{Backend: b}
+
+	for i := 0; i < 3; i++ {
+		if err := tm.Init(); err != nil {
+			t.Fatalf("Init %d: %v", i, err)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := tm.DeviceList(); err != nil {
+			t.Fatalf("DeviceList before Exit %d: %v", i, err)
+		}
+		if err := tm.Exit(); err != nil {
+			t.Fatalf("Exit %d: %v", i, err)
+		}
+	}
+	if b.inits != 1 || b.exits != 1 {
+		t.Errorf("backend inits, exits = %d, %d; want 1, 1", b.inits, b.exits)
+	}
+	if _, err := tm.DeviceList(); !errors.Is(err, ERR_NOT_INITED) {
+		t.Errorf("DeviceList after last Exit = %v, want ERR_NOT_INITED", err)
+	}
+	if err := tm.Exit(); err != nil || b.exits != 1 {
+		t.Errorf("extra Exit = %v with %d backend exits; want nil with 1", err, b.exits)
+	}
+}
+
+func TestInitExitPerBackend(t *testing.T) {
+	initErr := errors.New("init failed")
+	a := &countingBackend{FakeBackend: &FakeBackend{}}
+	b := &countingBackend{FakeBackend: &FakeBackend{InitErr: initErr}}
+	ta := &Tempered{Backend: a}
+	tb := &Tempered{Backend: b}
+
+	if err := ta.Init(); err != nil {
+		t.Fatalf("a Init: %v", err)
+	}
+	if err := tb.Init(); !errors.Is(err, ERR_INIT_FAILED) {
+		t.Errorf("b Init = %v, want its backend's ERR_INIT_FAILED", err)
+	}
+	if err := tb.Exit(); err != nil {
+		t.Errorf("b Exit = %v", err)
+	}
+	if err := ta.Exit(); err != nil {
+		t.Errorf("a Exit = %v", err)
+	}
+
+	if a.inits != 1 || a.exits != 1 {
+		t.Errorf("a inits, exits = %d, %d; want 1, 1", a.inits, a.exits)
+	}
+	if b.inits != 1 || b.exits != 0 {
+		t.Errorf("b inits, exits = %d, %d; want 1, 0", b.inits, b.exits)
+	}
+}
+
+func TestConcurrentInitExitRead(t *testing.T) {
+	fb := &FakeBackend{Devices: []*FakeDevice{{
+		Path:    "/dev/fake0",
+		Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_ALL, Temperature: 20, Humidity: 50}},
+	}}}
+	holder := &Tempered{Backend: fb}
+	if err := holder.Init(); err != nil {
+		t.Fatal(err)
+	}
+	tds, err := holder.DeviceList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	td := &tds[0]
+	if err := td.Open(); err != nil {
+		t.Fatal(err)
+	}
+
+	const iterations = 200
+	var wg sync.WaitGroup
+	errs := make(chan error, 8*iterations)
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				_, err := td.ReadAll()
+				if err == nil {
+					_, err = td.Temperature(0)
+				}
+				if err != nil && !errors.Is(err, ERR_NOT_INITED) {
+					errs <- err
+				}
+			}
+		}()
+	}
+	for g := 0; g < 2; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			other := &Tempered{Backend: fb}
+			for i := 0; i < iterations; i++ {
+				if err := other.Init(); err != nil {
+					errs <- err
+					return
+				}
+				if err := other.Exit(); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	// Drop the holder's reference part-way, so that the library really is
+	// torn down underneath the readers whenever the togglers are between
+	// Init and Exit.
+	if err := holder.Exit(); err != nil {
+		t.Fatal(err)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if _, err := td.ReadAll(); !errors.Is(err, ERR_NOT_INITED) {
+		t.Errorf("ReadAll after the last Exit = %v, want ERR_NOT_INITED", err)
+	}
+	td.Close()
+	if n := fb.OpenHandles(); n != 0 {
+		t.Errorf("OpenHandles() = %d after Close, want 0", n)
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	fb := &FakeBackend{Devices: []*FakeDevice{
+		{
+			Path:     "/dev/fake0",
+			TypeName: "TEMPer2",
+			Sensors: []FakeSensor{
+				{Type: TEMPERED_SENSOR_TYPE_TEMPERATURE, Temperature: 21.5},
+				{Type: TEMPERED_SENSOR_TYPE_TEMPERATURE, Temperature: -4},
+			},
+		},
+		{
+			Path:     "/dev/fake1",
+			TypeName: "TEMPerHUM",
+			Sensors:  []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_ALL, Temperature: 19.25, Humidity: 45.5}},
+		},
+	}}
+	tm := &Tempered{Backend: fb}
+	if err := tm.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer tm.Exit()
+
+	tds, err := tm.DeviceList()
+	if err != nil {
+		t.Fatalf("DeviceList: %v", err)
+	}
+	if len(tds) != 2 || tds[0].Path != "/dev/fake0" || tds[1].Path != "/dev/fake1" {
+		t.Fatalf("DeviceList = %v, want /dev/fake0 and /dev/fake1", tds)
+	}
+
+	type want struct {
+		typ         TemperedSensorType
+		temperature Celsius
+		humidity    float64
+	}
+	wants := map[string][]want{
+		"/dev/fake0": {{TEMPERED_SENSOR_TYPE_TEMPERATURE, 21.5, 0}, {TEMPERED_SENSOR_TYPE_TEMPERATURE, -4, 0}},
+		"/dev/fake1": {{TEMPERED_SENSOR_TYPE_ALL, 19.25, 45.5}},
+	}
+	for n := range tds {
+		td := &tds[n]
+		if err := td.Update(); !errors.Is(err, ERR_NOT_OPEN) {
+			t.Errorf("%s: Update before Open = %v, want ERR_NOT_OPEN", td.Path, err)
+		}
+		if err := td.Open(); err != nil {
+			t.Fatalf("%s: Open: %v", td.Path, err)
+		}
+		if err := td.Update(); err != nil {
+			t.Fatalf("%s: Update: %v", td.Path, err)
+		}
+
+		sensors, err := td.Sensors()
+		if err != nil {
+			t.Fatalf("%s: Sensors: %v", td.Path, err)
+		}
+		if len(sensors) != len(wants[td.Path]) {
+			t.Fatalf("%s: %d sensors, want %d", td.Path, len(sensors), len(wants[td.Path]))
+		}
+		for sn, w := range wants[td.Path] {
+			ts := sensors[sn]
+			if ts.TypeMask != w.typ {
+				t.Errorf("%s sensor %d: type %v, want %v", td.Path, sn, ts.TypeMask, w.typ)
+			}
+			if got, err := ts.Temperature(); err != nil || got != w.temperature {
+				t.Errorf("%s sensor %d: Temperature() = %v, %v; want %v, nil", td.Path, sn, got, err, w.temperature)
+			}
+			if got, err := td.Temperature(sn); err != nil || got != w.temperature {
+				t.Errorf("%s: Temperature(%d) = %v, %v; want %v, nil", td.Path, sn, got, err, w.temperature)
+			}
+			if w.typ.IsType(TEMPERED_SENSOR_TYPE_HUMIDITY) {
+				if got, err := ts.Humidity(); err != nil || got != w.humidity {
+					t.Errorf("%s sensor %d: Humidity() = %v, %v; want %v, nil", td.Path, sn, got, err, w.humidity)
+				}
+			} else if _, err := ts.Humidity(); !errors.Is(err, ERR_FAILED_RETRIEVE) {
+				// As with libtempered, asking for a measurement the sensor
+				// doesn't make is a failed retrieval.
+				t.Errorf("%s sensor %d: Humidity() = %v, want ERR_FAILED_RETRIEVE", td.Path, sn, err)
+			}
+		}
+		if _, err := td.Temperature(len(sensors)); !errors.Is(err, ERR_SENSOR_OUT_OF_RANGE) {
+			t.Errorf("%s: Temperature(%d) = %v, want ERR_SENSOR_OUT_OF_RANGE", td.Path, len(sensors), err)
+		}
+	}
+	if n := fb.OpenHandles(); n != 2 {
+		t.Errorf("OpenHandles() = %d with both devices open, want 2", n)
+	}
+
+	// Failures injected into the fake surface from the matching call.
+	fb.Lock()
+	fb.Devices[0].FailUpdate = true
+	fb.Devices[1].FailRead = true
+	fb.Unlock()
+	if err := tds[0].Update(); !errors.Is(err, ERR_FAILED_UPDATE) {
+		t.Errorf("Update with FailUpdate = %v, want ERR_FAILED_UPDATE", err)
+	}
+	if err := tds[1].Update(); err != nil {
+		t.Errorf("Update with FailRead = %v, want nil", err)
+	}
+	if _, err := tds[1].Humidity(0); !errors.Is(err, ERR_FAILED_RETRIEVE) {
+		t.Errorf("Humidity with FailRead = %v, want ERR_FAILED_RETRIEVE", err)
+	}
+
+	for n := range tds {
+		td := &tds[n]
+		if err := td.Close(); err != nil {
+			t.Errorf("%s: Close: %v", td.Path, err)
+		}
+		if err := td.Close(); err != nil {
+			t.Errorf("%s: second Close: %v", td.Path, err)
+		}
+		if _, err := td.Temperature(0); !errors.Is(err, ERR_NOT_OPEN) {
+			t.Errorf("%s: Temperature after Close = %v, want ERR_NOT_OPEN", td.Path, err)
+		}
+	}
+	if n := fb.OpenHandles(); n != 0 {
+		t.Errorf("OpenHandles() = %d after Close, want 0", n)
+	}
+
+	fb.Lock()
+	fb.Devices[0].OpenErr = errors.New("fake: busy")
+	fb.Unlock()
+	if err := tds[0].Open(); err == nil {
+		t.Error("Open with OpenErr set succeeded")
+	}
+	fb.Lock()
+	fb.EnumerateErr = errors.New("fake: bus error")
+	fb.Unlock()
+	if _, err := tm.DeviceList(); err == nil {
+		t.Error("DeviceList with EnumerateErr set succeeded")
+	}
+}
+
+func TestNegativeTemperatureRoundTrip(t *testing.T) {
+	const freezer = -18.5
+	fb := &FakeBackend{Devices: []*FakeDevice{{
+		Path:    "/dev/fake0",
+		Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_TEMPERATURE, Temperature: freezer}},
+	}}}
+	td := openFake(t, fb)
+
+	if err := td.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := td.Temperature(0); err != nil || got != freezer {
+		t.Errorf("Temperature(0) = %v, %v; want exactly %v", got, err, freezer)
+	}
+	rs, err := td.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := *rs[0].Temperature; got != freezer {
+		t.Errorf("ReadAll temperature = %v, want exactly %v", got, freezer)
+	}
+	// libtempered hands the value over as a C float; -18.5 survives that
+	// narrowing exactly too.
+	if got := float64(float32(freezer)); got != freezer {
+		t.Errorf("float64(float32(%v)) = %v", freezer, got)
+	}
+}
+
+func TestSensorCountChange(t *testing.T) {
+	fb := &FakeBackend{Devices: []*FakeDevice{{
+		Path:    "/dev/fake0",
+		Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_TEMPERATURE, Temperature: 20}},
+	}}}
+	td := openFake(t, fb)
+
+	if err := td.Update(); err != nil {
+		t.Fatal(err)
+	}
+	sensors, err := td.Sensors()
+	if err != nil || len(sensors) != 1 {
+		t.Fatalf("Sensors() = %d sensors, %v; want 1, nil", len(sensors), err)
+	}
+	sensors[0].Label = "inside"
+	if again, _ := td.Sensors(); again[0].Label != "inside" {
+		t.Error("Sensors() lost a label although the sensor count didn't change")
+	}
+
+	// The device renumbers, gaining a humidity sensor.
+	fb.Lock()
+	fb.Devices[0].Sensors = []FakeSensor{
+		{Type: TEMPERED_SENSOR_TYPE_TEMPERATURE, Temperature: 20},
+		{Type: TEMPERED_SENSOR_TYPE_ALL, Temperature: 18, Humidity: 60},
+	}
+	fb.Unlock()
+	if err := td.Update(); err != nil {
+		t.Fatal(err)
+	}
+	sensors, err = td.Sensors()
+	if err != nil || len(sensors) != 2 {
+		t.Fatalf("Sensors() after the change = %d sensors, %v; want 2, nil", len(sensors), err)
+	}
+	if sensors[1].TypeMask != TEMPERED_SENSOR_TYPE_ALL {
+		t.Errorf("new sensor type = %v, want %v", sensors[1].TypeMask, TEMPERED_SENSOR_TYPE_ALL)
+	}
+	if got, err := sensors[1].Humidity(); err != nil || got != 60 {
+		t.Errorf("new sensor Humidity() = %v, %v; want 60, nil", got, err)
+	}
+	if sensors[0].Label != "" {
+		t.Errorf("rebuilt sensor kept stale label %q", sensors[0].Label)
+	}
+}
+
+// TestConcurrentDeviceUse is meant for go test -race.
+func TestConcurrentDeviceUse(t *testing.T) {
+	fb := &FakeBackend{Devices: []*FakeDevice{{
+		Path:    "/dev/fake0",
+		Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_ALL, Temperature: 20, Humidity: 50}},
+	}}}
+	td := openFake(t, fb)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				var err error
+				switch (g + i) % 5 {
+				case 0:
+					err = td.Update()
+				case 1:
+					_, err = td.ReadAll()
+				case 2:
+					if err = td.Update(); err == nil {
+						_, err = td.Temperature(0)
+					}
+				case 3:
+					td.LastReadLatency()
+				case 4:
+					td.Age()
+				}
+				if err != nil {
+					t.Errorf("goroutine %d: %v", g, err)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if age := td.Age(); age < 0 || age > time.Minute {
+		t.Errorf("Age() = %v after the updates, want a small positive duration", age)
+	}
+}
+
+func TestHandMadeDevicesDoNotShareALock(t *testing.T) {
+	fb := &FakeBackend{Devices: []*FakeDevice{
+		{Path: "/dev/fake0", Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_TEMPERATURE}}},
+		{Path: "/dev/fake1", Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_TEMPERATURE}}},
+	}}
+	tm := &Tempered{Backend: fb}
+	if err := tm.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer tm.Exit()
+	tds, err := tm.DeviceList()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Strip the locks DeviceList gave them, as for devices built by hand.
+	a, b := tds[0], tds[1]
+	a.lock, b.lock = nil, nil
+
+	// A call hung on a holds its lock; b must carry on regardless.
+	a.mutex().Lock()
+	defer a.mutex().Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		if err := b.Open(); err != nil {
+			done <- err
+			return
+		}
+		err := b.Update()
+		b.Close()
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("a device blocked on another device's lock")
+	}
+}
@@ -0,0 +1,92 @@
+package temperedgo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Topology is a snapshot of the attached devices and their sensors, suitable
+// for rendering in a UI or as JSON.
+type Topology struct {
+	Devices []TopologyDevice `json:"devices"`
+}
+
+// TopologyDevice is a device in a Topology. Error is set, and Sensors empty,
+// when the device could not be opened or read.
+type TopologyDevice struct {
+	ID              string           `json:"id"`
+	TypeName        string           `json:"type_name"`
+	VendorId        uint             `json:"vendor_id"`
+	ProductId       uint             `json:"product_id"`
+	InterfaceNumber int              `json:"interface_number"`
+	Sensors         []TopologySensor `json:"sensors,omitempty"`
+	Error           string           `json:"error,omitempty"`
+}
+
+type TopologySensor struct {
+	Index int      `json:"index"`
+	Role  string   `json:"role"`
+	Types []string `json:"types"`
+}
+
+func topologyDevice(td TemperedDevice) TopologyDevice {
+	tdev := TopologyDevice{
+		ID:              td.ID(),
+		TypeName:        td.TypeName,
+		VendorId:        td.VendorId,
+		ProductId:       td.ProductId,
+		InterfaceNumber: td.InterfaceNumber,
+	}
+
+	dev := td
+	if err := dev.Open(); err != nil {
+		tdev.Error = err.Error()
+		return tdev
+	}
+	defer dev.Close()
+
+	sensors, err := dev.SortedSensors()
+	if err != nil {
+		tdev.Error = err.Error()
+		return tdev
+	}
+	for _, ts := range sensors {
+		tdev.Sensors = append(tdev.Sensors, TopologySensor{
+			Index: ts.sensorNum,
+			Role:  ts.Role.String(),
+			Types: ts.TypeMask.Names(),
+		})
+	}
+	return tdev
+}
+
+// Topology enumerates devices and briefly opens each to list its sensors.
+func (t *Tempered) Topology() (Topology, error) {
+	tds, err := t.DeviceList()
+	if err != nil {
+		return Topology{}, err
+	}
+
+	topo := Topology{Devices: make([]TopologyDevice, 0, len(tds))}
+	for _, td := range tds {
+		topo.Devices = append(topo.Devices, topologyDevice(td))
+	}
+	return topo, nil
+}
+
+// String renders the topology as an indented tree, one line per device and
+// sensor.
+func (topo Topology) String() string {
+	var b strings.Builder
+	for _, td := range topo.Devices {
+		fmt.Fprintf(&b, "%s (%s, %04x:%04x)\n", td.ID, td.TypeName, td.VendorId, td.ProductId)
+		if td.Error != "" {
+			fmt.Fprintf(&b, "  error: %s\n", td.Error)
+			continue
+		}
+		for _, ts := range td.Sensors {
+			fmt.Fprintf(&b, "  sensor %d (%s): %s\n", ts.Index, ts.Role, strings.Join(ts.Types, ", "))
+		}
+	}
+	return b.String()
+}
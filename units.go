@@ -0,0 +1,166 @@
+package temperedgo
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+type TemperatureUnit int
+
+const (
+	TEMPERATURE_UNIT_CELSIUS TemperatureUnit = iota
+	TEMPERATURE_UNIT_FAHRENHEIT
+	TEMPERATURE_UNIT_KELVIN
+)
+
+func (u TemperatureUnit) String() string {
+	switch u {
+	case TEMPERATURE_UNIT_CELSIUS:
+		return "celsius"
+	case TEMPERATURE_UNIT_FAHRENHEIT:
+		return "fahrenheit"
+	case TEMPERATURE_UNIT_KELVIN:
+		return "kelvin"
+	}
+	return fmt.Sprintf("TemperatureUnit(%d)", int(u))
+}
+
+// FromCelsius converts a temperature in degrees Celsius to u.
+func (u TemperatureUnit) FromCelsius(tempC float64) float64 {
+	switch u {
+	case TEMPERATURE_UNIT_FAHRENHEIT:
+		return tempC*9/5 + 32
+	case TEMPERATURE_UNIT_KELVIN:
+		return tempC + 273.15
+	}
+	return tempC
+}
+
+// scale is the size of one degree of u in degrees Celsius, for converting
+// temperature differences, which aren't offset.
+func (u TemperatureUnit) scale() float64 {
+	if u == TEMPERATURE_UNIT_FAHRENHEIT {
+		return 9.0 / 5
+	}
+	return 1
+}
+
+// ParseTemperatureUnit accepts "c", "celsius", "f", "fahrenheit", "k" and
+// "kelvin", ignoring case and surrounding whitespace.
+func ParseTemperatureUnit(s string) (TemperatureUnit, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "c", "celsius":
+		return TEMPERATURE_UNIT_CELSIUS, nil
+	case "f", "fahrenheit":
+		return TEMPERATURE_UNIT_FAHRENHEIT, nil
+	case "k", "kelvin":
+		return TEMPERATURE_UNIT_KELVIN, nil
+	}
+	return 0, fmt.Errorf("tempered: unknown temperature unit %q", s)
+}
+
+var (
+	defaultUnitLock sync.RWMutex
+	defaultUnit     TemperatureUnit
+)
+
+// SetDefaultUnit sets the unit TemperatureInDefault converts to. The default
+// is TEMPERATURE_UNIT_CELSIUS. It affects only TemperatureInDefault:
+// Temperature, ReadAll and everything built on them keep returning Celsius,
+// so callers must switch to TemperatureInDefault to see the change.
+func SetDefaultUnit(u TemperatureUnit) {
+	defaultUnitLock.Lock()
+	defer defaultUnitLock.Unlock()
+
+	defaultUnit = u
+}
+
+// DefaultUnit returns the unit set by SetDefaultUnit.
+func DefaultUnit() TemperatureUnit {
+	defaultUnitLock.RLock()
+	defer defaultUnitLock.RUnlock()
+
+	return defaultUnit
+}
+
+// TemperatureIn is Temperature converted to unit.
+func (t *TemperedDevice) TemperatureIn(sensorNum int, unit TemperatureUnit) (float64, error) {
+	tempC, err := t.Temperature(sensorNum)
+	if err != nil {
+		return 0, err
+	}
+	return unit.FromCelsius(tempC.Float64()), nil
+}
+
+// TemperatureInDefault is TemperatureIn for the unit set by SetDefaultUnit.
+func (t *TemperedDevice) TemperatureInDefault(sensorNum int) (float64, error) {
+	return t.TemperatureIn(sensorNum, DefaultUnit())
+}
+
+// ReadAllUnit is ReadAll with temperatures converted to unit. The returned
+// Readings hold temperatures in that unit rather than Celsius, so anything
+// consuming them (JSON, exposition, CSV) must be told which unit is in use.
+// Humidity is unaffected.
+func (t *TemperedDevice) ReadAllUnit(unit TemperatureUnit) ([]Reading, error) {
+	rs, err := t.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	for n := range rs {
+		if rs[n].Temperature != nil {
+			val := unit.FromCelsius(*rs[n].Temperature)
+			rs[n].Temperature = &val
+		}
+		if rs[n].TemperatureUncertainty != nil {
+			// An uncertainty is a difference, so it scales but isn't offset.
+			val := *rs[n].TemperatureUncertainty * unit.scale()
+			rs[n].TemperatureUncertainty = &val
+		}
+	}
+	return rs, nil
+}
+
+// Celsius, Fahrenheit and Kelvin are temperatures in those units, kept as
+// distinct types so that the compiler catches one being used as another.
+type (
+	Celsius    float64
+	Fahrenheit float64
+	Kelvin     float64
+)
+
+func (c Celsius) Float64() float64 {
+	return float64(c)
+}
+
+func (c Celsius) Fahrenheit() Fahrenheit {
+	return Fahrenheit(c*9/5 + 32)
+}
+
+func (c Celsius) Kelvin() Kelvin {
+	return Kelvin(c + 273.15)
+}
+
+func (f Fahrenheit) Float64() float64 {
+	return float64(f)
+}
+
+func (f Fahrenheit) Celsius() Celsius {
+	return Celsius((f - 32) * 5 / 9)
+}
+
+func (f Fahrenheit) Kelvin() Kelvin {
+	return f.Celsius().Kelvin()
+}
+
+func (k Kelvin) Float64() float64 {
+	return float64(k)
+}
+
+func (k Kelvin) Celsius() Celsius {
+	return Celsius(k - 273.15)
+}
+
+func (k Kelvin) Fahrenheit() Fahrenheit {
+	return k.Celsius().Fahrenheit()
+}
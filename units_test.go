@@ -0,0 +1,90 @@
+package temperedgo
+
+import (
+	"testing"
+)
+
+func TestTemperatureIn(t *testing.T) {
+	tests := []struct {
+		name                  string
+		celsius, fahr, kelvin float64
+	}{
+		{"absolute zero", -273.15, -459.66999999999996, 0},
+		{"minus forty", -40, -40, 233.14999999999998},
+		{"freezing", 0, 32, 273.15},
+		{"boiling", 100, 212, 373.15},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fb := &FakeBackend{Devices: []*FakeDevice{{
+				Path:    "/dev/fake0",
+				Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_TEMPERATURE, Temperature: tt.celsius}},
+			}}}
+			td := openFake(t, fb)
+			if err := td.Update(); err != nil {
+				t.Fatalf("Update: %v", err)
+			}
+
+			for unit, want := range map[TemperatureUnit]float64{
+				TEMPERATURE_UNIT_CELSIUS:    tt.celsius,
+				TEMPERATURE_UNIT_FAHRENHEIT: tt.fahr,
+				TEMPERATURE_UNIT_KELVIN:     tt.kelvin,
+			} {
+				got, err := td.TemperatureIn(0, unit)
+				if err != nil {
+					t.Fatalf("TemperatureIn(0, %v): %v", unit, err)
+				}
+				if got != want {
+					t.Errorf("TemperatureIn(0, %v) = %v, want %v", unit, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSetDefaultUnitLeavesTemperatureCelsius(t *testing.T) {
+	fb := &FakeBackend{Devices: []*FakeDevice{{
+		Path:    "/dev/fake0",
+		Sensors: []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_TEMPERATURE, Temperature: 100}},
+	}}}
+	td := openFake(t, fb)
+	if err := td.Update(); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+
+	SetDefaultUnit(TEMPERATURE_UNIT_FAHRENHEIT)
+	defer SetDefaultUnit(TEMPERATURE_UNIT_CELSIUS)
+
+	if got, err := td.TemperatureInDefault(0); err != nil || got != 212 {
+		t.Errorf("TemperatureInDefault(0) = %v, %v, want 212, nil", got, err)
+	}
+	if got, err := td.Temperature(0); err != nil || got != 100 {
+		t.Errorf("Temperature(0) = %v, %v, want 100, nil", got, err)
+	}
+}
+
+func TestReadAllUnitUncertainty(t *testing.T) {
+	SetAccuracy("TEMPerUnitTest", AccuracySpec{Temperature: 0.3})
+	fb := &FakeBackend{Devices: []*FakeDevice{{
+		Path:     "/dev/fake0",
+		TypeName: "TEMPerUnitTest",
+		Sensors:  []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_TEMPERATURE, Temperature: 20}},
+	}}}
+	td := openFake(t, fb)
+
+	for unit, want := range map[TemperatureUnit]float64{
+		TEMPERATURE_UNIT_CELSIUS:    0.3,
+		TEMPERATURE_UNIT_FAHRENHEIT: 0.3 * 9 / 5,
+		TEMPERATURE_UNIT_KELVIN:     0.3,
+	} {
+		rs, err := td.ReadAllUnit(unit)
+		if err != nil {
+			t.Fatalf("ReadAllUnit(%v): %v", unit, err)
+		}
+		if u := rs[0].TemperatureUncertainty; u == nil {
+			t.Errorf("ReadAllUnit(%v) has no uncertainty, want %v", unit, want)
+		} else if *u != want {
+			t.Errorf("ReadAllUnit(%v) uncertainty = %v, want exactly %v", unit, *u, want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package temperedgo
+
+import (
+	"fmt"
+	"time"
+)
+
+// Validator checks a reading, returning an error describing why it should
+// not be trusted.
+type Validator interface {
+	Validate(r Reading) error
+}
+
+// ValidatorFunc adapts a function to a Validator.
+type ValidatorFunc func(r Reading) error
+
+func (f ValidatorFunc) Validate(r Reading) error {
+	return f(r)
+}
+
+// ValidatorChain runs its validators in order, returning the first failure.
+type ValidatorChain []Validator
+
+func (vc ValidatorChain) Validate(r Reading) error {
+	for _, v := range vc {
+		if err := v.Validate(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PlausibleValidator rejects values outside what TEMPer hardware can report,
+// as HealthCheck does.
+var PlausibleValidator Validator = ValidatorFunc(checkPlausible)
+
+// RangeValidator rejects temperatures and humidities outside the given
+// inclusive bounds with ERR_IMPLAUSIBLE. Measurements the reading lacks are
+// not checked.
+type RangeValidator struct {
+	MinTemperature, MaxTemperature float64
+	MinHumidity, MaxHumidity       float64
+}
+
+func (rv RangeValidator) Validate(r Reading) error {
+	if r.Temperature != nil && (*r.Temperature < rv.MinTemperature || *r.Temperature > rv.MaxTemperature) {
+		return fmt.Errorf("sensor %d temperature %g°C outside [%g, %g]: %w", r.SensorNum, *r.Temperature, rv.MinTemperature, rv.MaxTemperature, ERR_IMPLAUSIBLE)
+	}
+	if r.Humidity != nil && (*r.Humidity < rv.MinHumidity || *r.Humidity > rv.MaxHumidity) {
+		return fmt.Errorf("sensor %d humidity %g%%RH outside [%g, %g]: %w", r.SensorNum, *r.Humidity, rv.MinHumidity, rv.MaxHumidity, ERR_IMPLAUSIBLE)
+	}
+	return nil
+}
+
+// StalenessValidator rejects readings taken more than MaxAge ago with
+// ERR_STALE_READING.
+type StalenessValidator struct {
+	MaxAge time.Duration
+}
+
+func (sv StalenessValidator) Validate(r Reading) error {
+	if age := time.Since(r.Time); age > sv.MaxAge {
+		return fmt.Errorf("sensor %d reading %v old: %w", r.SensorNum, age.Round(time.Millisecond), ERR_STALE_READING)
+	}
+	return nil
+}
+
+// ValidatedReading is a Reading with the outcome of validating it.
+type ValidatedReading struct {
+	Reading
+	Err error
+}
+
+// ReadAllValidated is ReadAll, with each reading annotated with the result
+// of v. Readings that fail validation are still returned.
+func (t *TemperedDevice) ReadAllValidated(v Validator) ([]ValidatedReading, error) {
+	rs, err := t.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	vrs := make([]ValidatedReading, len(rs))
+	for n, r := range rs {
+		vrs[n] = ValidatedReading{Reading: r, Err: v.Validate(r)}
+	}
+	return vrs, nil
+}
@@ -0,0 +1,103 @@
+package temperedgo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Watchdog polls a device and recovers it when it stalls. If no read has
+// succeeded for Timeout the device is reset, closing and reopening it;
+// after MaxResets resets in a row fail to produce a good read the library
+// is re-inited as well. Because Init and Exit are reference counted, that
+// only really restarts libtempered if nothing else holds it inited.
+type Watchdog struct {
+	Tempered  *Tempered
+	Device    *TemperedDevice
+	Interval  time.Duration
+	Timeout   time.Duration
+	MaxResets int
+
+	// OnReading, if set, is called with each successful read.
+	OnReading func([]Reading)
+
+	lock     sync.Mutex
+	restarts int
+	reinits  int
+}
+
+// Restarts is how many times the watchdog has reset the device or
+// re-inited the library.
+func (w *Watchdog) Restarts() int {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.restarts
+}
+
+// Reinits is how many of the Restarts re-inited the library.
+func (w *Watchdog) Reinits() int {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.reinits
+}
+
+// Run polls every Interval until ctx is done. Interval must be positive.
+func (w *Watchdog) Run(ctx context.Context) error {
+	if w.Interval <= 0 {
+		return errors.New("tempered: watchdog interval must be positive")
+	}
+
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	lastOK := time.Now()
+	failedResets := 0
+	for {
+		if rs, err := w.Device.ReadAll(); err == nil {
+			lastOK = time.Now()
+			failedResets = 0
+			if w.OnReading != nil {
+				w.OnReading(rs)
+			}
+		} else if time.Since(lastOK) >= w.Timeout {
+			if failedResets >= w.MaxResets {
+				w.reinit()
+				failedResets = 0
+			} else {
+				w.reset()
+				failedResets++
+			}
+			// Give the recovered device a full timeout before judging it.
+			lastOK = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Watchdog) reset() {
+	w.lock.Lock()
+	w.restarts++
+	w.lock.Unlock()
+
+	w.Device.Reset()
+}
+
+func (w *Watchdog) reinit() {
+	w.lock.Lock()
+	w.restarts++
+	w.reinits++
+	w.lock.Unlock()
+
+	w.Device.Close()
+	w.Tempered.Exit()
+	if err := w.Tempered.Init(); err != nil {
+		return
+	}
+	w.Device.Open()
+}
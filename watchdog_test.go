@@ -0,0 +1,84 @@
+package temperedgo
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWatchdogValidates(t *testing.T) {
+	w := &Watchdog{}
+	if err := w.Run(context.Background()); err == nil {
+		t.Error("Run with a zero Interval succeeded, want an error")
+	}
+}
+
+func TestWatchdogRecovers(t *testing.T) {
+	fb := &FakeBackend{Devices: []*FakeDevice{{
+		Path:       "/dev/fake0",
+		Sensors:    []FakeSensor{{Type: TEMPERED_SENSOR_TYPE_TEMPERATURE, Temperature: 20}},
+		FailUpdate: true,
+	}}}
+	tm := &Tempered{Backend: fb}
+	if err := tm.Init(); err != nil {
+		t.Fatal(err)
+	}
+	defer tm.exitAll()
+	tds, err := tm.DeviceList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	td := &tds[0]
+	if err := td.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer td.Close()
+
+	var readsLock sync.Mutex
+	reads := 0
+	w := &Watchdog{
+		Tempered:  tm,
+		Device:    td,
+		Interval:  time.Millisecond,
+		Timeout:   5 * time.Millisecond,
+		MaxResets: 2,
+		OnReading: func([]Reading) {
+			readsLock.Lock()
+			reads++
+			readsLock.Unlock()
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+
+	// Two resets fail to help, so the library is re-inited next.
+	waitFor(t, "the library to be re-inited", func() bool { return w.Reinits() > 0 })
+	if got := w.Restarts(); got < 3 {
+		t.Errorf("Restarts() = %d on the first re-init, want at least 3", got)
+	}
+
+	fb.Lock()
+	fb.Devices[0].FailUpdate = false
+	fb.Unlock()
+	waitFor(t, "a successful read", func() bool {
+		readsLock.Lock()
+		defer readsLock.Unlock()
+		return reads > 0
+	})
+
+	// Once reads succeed, the watchdog leaves the device alone.
+	restarts := w.Restarts()
+	time.Sleep(20 * time.Millisecond)
+	if got := w.Restarts(); got != restarts {
+		t.Errorf("Restarts() rose from %d to %d while reads were succeeding", restarts, got)
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run = %v, want context.Canceled", err)
+	}
+}